@@ -1,5 +1,7 @@
 package gophercloud
 
+import "crypto/tls"
+
 /*
 AuthOptions stores information needed to authenticate to an OpenStack Cloud.
 You can populate one manually, or use a provider's AuthOptionsFromEnv() function
@@ -82,8 +84,30 @@ type AuthOptions struct {
 	// authentication token ID.
 	TokenID string `json:"-"`
 
+	// ApplicationCredentialID and ApplicationCredentialName, together with
+	// ApplicationCredentialSecret, authenticate with a Keystone application
+	// credential instead of a password, which requires Identity V3. An
+	// application credential is identified either by its ID alone, or by its
+	// name and its user, given as UserID, or as Username with DomainID or
+	// DomainName.
+	//
+	// The token is scoped to the application credential's project, with its
+	// roles, so Scope, TenantID and TenantName must not be set.
+	ApplicationCredentialID     string `json:"-"`
+	ApplicationCredentialName   string `json:"-"`
+	ApplicationCredentialSecret string `json:"-"`
+
 	// Scope determines the scoping of the authentication request.
 	Scope *AuthScope `json:"-"`
+
+	// TLSConfig, if set, is used by a provider's AuthenticatedClient for every
+	// request the ProviderClient makes, including authentication itself. Set
+	// its Certificates to present a client certificate to endpoints that
+	// require mutual TLS, and its RootCAs to trust a private CA.
+	//
+	// When calling Authenticate on a client created with NewClient instead,
+	// set the TLS configuration on the ProviderClient's HTTPClient directly.
+	TLSConfig *tls.Config `json:"-"`
 }
 
 // AuthScope allows a created token to be limited to a specific domain or project.
@@ -154,10 +178,24 @@ func (opts *AuthOptions) ToTokenV3CreateMap(scope map[string]interface{}) (map[s
 		ID string `json:"id"`
 	}
 
+	type appCredUserReq struct {
+		ID     *string    `json:"id,omitempty"`
+		Name   *string    `json:"name,omitempty"`
+		Domain *domainReq `json:"domain,omitempty"`
+	}
+
+	type applicationCredentialReq struct {
+		ID     *string         `json:"id,omitempty"`
+		Name   *string         `json:"name,omitempty"`
+		User   *appCredUserReq `json:"user,omitempty"`
+		Secret string          `json:"secret"`
+	}
+
 	type identityReq struct {
-		Methods  []string     `json:"methods"`
-		Password *passwordReq `json:"password,omitempty"`
-		Token    *tokenReq    `json:"token,omitempty"`
+		Methods               []string                  `json:"methods"`
+		Password              *passwordReq              `json:"password,omitempty"`
+		Token                 *tokenReq                 `json:"token,omitempty"`
+		ApplicationCredential *applicationCredentialReq `json:"application_credential,omitempty"`
 	}
 
 	type authReq struct {
@@ -194,6 +232,46 @@ func (opts *AuthOptions) ToTokenV3CreateMap(scope map[string]interface{}) (map[s
 			req.Auth.Identity.Token = &tokenReq{
 				ID: opts.TokenID,
 			}
+		} else if opts.ApplicationCredentialID != "" || opts.ApplicationCredentialName != "" {
+			if opts.ApplicationCredentialSecret == "" {
+				return nil, ErrAppCredMissingSecret{}
+			}
+
+			// Configure the request for ApplicationCredential authentication.
+			req.Auth.Identity.Methods = []string{"application_credential"}
+			appCred := &applicationCredentialReq{Secret: opts.ApplicationCredentialSecret}
+			req.Auth.Identity.ApplicationCredential = appCred
+
+			if opts.ApplicationCredentialID != "" {
+				// An ID identifies the application credential on its own.
+				if opts.ApplicationCredentialName != "" {
+					return nil, ErrAppCredIDOrName{}
+				}
+				appCred.ID = &opts.ApplicationCredentialID
+			} else {
+				// A name only identifies it together with its user.
+				appCred.Name = &opts.ApplicationCredentialName
+				switch {
+				case opts.UserID != "":
+					if opts.Username != "" {
+						return nil, ErrUsernameOrUserID{}
+					}
+					appCred.User = &appCredUserReq{ID: &opts.UserID}
+				case opts.Username != "":
+					if (opts.DomainID == "") == (opts.DomainName == "") {
+						return nil, ErrDomainIDOrDomainName{}
+					}
+					user := &appCredUserReq{Name: &opts.Username}
+					if opts.DomainID != "" {
+						user.Domain = &domainReq{ID: &opts.DomainID}
+					} else {
+						user.Domain = &domainReq{Name: &opts.DomainName}
+					}
+					appCred.User = user
+				default:
+					return nil, ErrUsernameOrUserID{}
+				}
+			}
 		} else {
 			// If no password or token ID are available, authentication can't continue.
 			return nil, ErrMissingPassword{}
@@ -274,6 +352,15 @@ func (opts *AuthOptions) ToTokenV3CreateMap(scope map[string]interface{}) (map[s
 }
 
 func (opts *AuthOptions) ToTokenV3ScopeMap() (map[string]interface{}, error) {
+	// An application credential carries its own scope, and Keystone rejects
+	// requests for another one.
+	if opts.ApplicationCredentialID != "" || opts.ApplicationCredentialName != "" {
+		if opts.TenantID != "" || opts.TenantName != "" || (opts.Scope != nil && *opts.Scope != AuthScope{}) {
+			return nil, ErrAppCredWithScope{}
+		}
+		return nil, nil
+	}
+
 	// For backwards compatibility.
 	// If AuthOptions.Scope was not set, try to determine it.
 	// This works well for common scenarios.
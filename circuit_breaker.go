@@ -0,0 +1,134 @@
+package gophercloud
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Default values for the CircuitBreaker settings.
+const (
+	DefaultCircuitBreakerThreshold = 5
+	DefaultCircuitBreakerCooldown  = 30 * time.Second
+)
+
+// CircuitBreaker stops a ProviderClient from sending requests to an endpoint
+// that keeps failing. After Threshold consecutive failures to an endpoint,
+// the circuit for that endpoint opens and further requests to it fail
+// immediately with an ErrCircuitOpen. Once Cooldown has elapsed, the circuit
+// half-opens: a single request is let through as a probe, and its outcome
+// either closes the circuit again or reopens it for another Cooldown.
+//
+// Endpoints are told apart by the scheme and host of the request URL. A
+// failure is a request that gets no response, or a 502, 503 or 504 response;
+// any other response, including errors such as 404, shows the endpoint is up.
+// A request whose context is cancelled or times out is not counted.
+//
+// A CircuitBreaker is safe for concurrent use and may be shared between
+// ProviderClients.
+type CircuitBreaker struct {
+	// Threshold is the number of consecutive failures that opens a circuit.
+	// It defaults to DefaultCircuitBreakerThreshold.
+	Threshold int
+
+	// Cooldown is how long a circuit stays open before a probe request is
+	// allowed. It defaults to DefaultCircuitBreakerCooldown.
+	Cooldown time.Duration
+
+	mut       sync.Mutex
+	endpoints map[string]*circuit
+}
+
+// circuit is the state of the circuit for one endpoint.
+type circuit struct {
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// circuitKey returns the endpoint a request URL belongs to.
+func circuitKey(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+func (cb *CircuitBreaker) threshold() int {
+	if cb.Threshold > 0 {
+		return cb.Threshold
+	}
+	return DefaultCircuitBreakerThreshold
+}
+
+func (cb *CircuitBreaker) cooldown() time.Duration {
+	if cb.Cooldown > 0 {
+		return cb.Cooldown
+	}
+	return DefaultCircuitBreakerCooldown
+}
+
+// allow returns an ErrCircuitOpen if a request to endpoint must not be sent.
+// When it lets a probe through, the caller must report its outcome to
+// record.
+func (cb *CircuitBreaker) allow(endpoint string) error {
+	cb.mut.Lock()
+	defer cb.mut.Unlock()
+
+	c, ok := cb.endpoints[endpoint]
+	if !ok || c.failures < cb.threshold() {
+		return nil
+	}
+
+	retryAt := c.openedAt.Add(cb.cooldown())
+	if c.probing || time.Now().Before(retryAt) {
+		return ErrCircuitOpen{Endpoint: endpoint, RetryAt: retryAt}
+	}
+	c.probing = true
+	return nil
+}
+
+// record reports the outcome of a request to endpoint, sent with ctx.
+func (cb *CircuitBreaker) record(ctx context.Context, endpoint string, resp *http.Response, err error) {
+	cb.mut.Lock()
+	defer cb.mut.Unlock()
+
+	// A request the caller cancelled, or whose deadline passed, says nothing
+	// about the endpoint. If it was the probe, let another one through.
+	if err != nil && ctx.Err() != nil {
+		if c, ok := cb.endpoints[endpoint]; ok {
+			c.probing = false
+		}
+		return
+	}
+
+	failed := err != nil
+	if resp != nil {
+		switch resp.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			failed = true
+		}
+	}
+
+	if !failed {
+		delete(cb.endpoints, endpoint)
+		return
+	}
+
+	if cb.endpoints == nil {
+		cb.endpoints = make(map[string]*circuit)
+	}
+	c, ok := cb.endpoints[endpoint]
+	if !ok {
+		c = new(circuit)
+		cb.endpoints[endpoint] = c
+	}
+	c.failures++
+	c.probing = false
+	if c.failures >= cb.threshold() {
+		c.openedAt = time.Now()
+	}
+}
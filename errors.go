@@ -3,6 +3,7 @@ package gophercloud
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 // BaseError is an error type that all other error types embed.
@@ -82,16 +83,35 @@ type ErrUnexpectedResponseCode struct {
 	Expected []int
 	Actual   int
 	Body     []byte
+
+	// BodyTruncated is set if Body holds only the beginning of the response
+	// body, because the body exceeded ProviderClient.MaxErrorBodySize or
+	// could not be read in full.
+	BodyTruncated bool
 }
 
 func (e ErrUnexpectedResponseCode) Error() string {
 	e.DefaultErrString = fmt.Sprintf(
 		"Expected HTTP response code %v when accessing [%s %s], but got %d instead\n%s",
-		e.Expected, e.Method, e.URL, e.Actual, e.Body,
+		e.Expected, e.Method, e.URL, e.Actual, e.body(),
 	)
 	return e.choseErrString()
 }
 
+// body returns the response body for an error message, marked if it was
+// truncated.
+func (e ErrUnexpectedResponseCode) body() string {
+	if e.BodyTruncated {
+		return string(e.Body) + "\n[response body truncated]"
+	}
+	return string(e.Body)
+}
+
+// GetStatusCode returns the actual status code of the response.
+func (e ErrUnexpectedResponseCode) GetStatusCode() int {
+	return e.Actual
+}
+
 // ErrDefault400 is the default error type returned on a 400 HTTP response code.
 type ErrDefault400 struct {
 	ErrUnexpectedResponseCode
@@ -140,7 +160,7 @@ type ErrDefault503 struct {
 func (e ErrDefault400) Error() string {
 	e.DefaultErrString = fmt.Sprintf(
 		"Bad request with: [%s %s], error message: %s",
-		e.Method, e.URL, e.Body,
+		e.Method, e.URL, e.body(),
 	)
 	return e.choseErrString()
 }
@@ -150,7 +170,7 @@ func (e ErrDefault401) Error() string {
 func (e ErrDefault403) Error() string {
 	e.DefaultErrString = fmt.Sprintf(
 		"Request forbidden: [%s %s], error message: %s",
-		e.Method, e.URL, e.Body,
+		e.Method, e.URL, e.body(),
 	)
 	return e.choseErrString()
 }
@@ -168,7 +188,11 @@ func (e ErrDefault429) Error() string {
 		" requests, wait up to one minute, and try again."
 }
 func (e ErrDefault500) Error() string {
-	return "Internal Server Error"
+	e.DefaultErrString = fmt.Sprintf(
+		"Internal Server Error: [%s %s], error message: %s",
+		e.Method, e.URL, e.body(),
+	)
+	return e.choseErrString()
 }
 func (e ErrDefault503) Error() string {
 	return "The service is currently unable to handle the request due to a temporary" +
@@ -445,9 +469,74 @@ func (e ErrScopeProjectIDAlone) Error() string {
 	return "ProjectID must be supplied alone in a Scope"
 }
 
+// ErrAppCredMissingSecret indicates that an application credential was
+// provided without its secret.
+type ErrAppCredMissingSecret struct{ BaseError }
+
+func (e ErrAppCredMissingSecret) Error() string {
+	return "You must provide an ApplicationCredentialSecret to authenticate with an application credential"
+}
+
+// ErrAppCredIDOrName indicates that both an application credential ID and
+// name were provided.
+type ErrAppCredIDOrName struct{ BaseError }
+
+func (e ErrAppCredIDOrName) Error() string {
+	return "You must provide exactly one of ApplicationCredentialID or ApplicationCredentialName"
+}
+
+// ErrAppCredWithScope indicates that a Scope, TenantID or TenantName was
+// provided, but application credential authentication is being used, whose
+// scope is implied by the application credential.
+type ErrAppCredWithScope struct{ BaseError }
+
+func (e ErrAppCredWithScope) Error() string {
+	return "You may not provide a Scope, TenantID or TenantName when authenticating with an application credential"
+}
+
 // ErrScopeEmpty indicates that no credentials were provided in a Scope.
 type ErrScopeEmpty struct{ BaseError }
 
 func (e ErrScopeEmpty) Error() string {
 	return "You must provide either a Project or Domain in a Scope"
 }
+
+// ErrTimeout is the error returned by Poll when its timeout elapses before the
+// condition is met.
+type ErrTimeout struct {
+	BaseError
+	Timeout time.Duration
+}
+
+func (e ErrTimeout) Error() string {
+	e.DefaultErrString = fmt.Sprintf("A timeout occurred after %s", e.Timeout)
+	return e.choseErrString()
+}
+
+// ErrCircuitOpen is the error when a request is not sent because the
+// ProviderClient's CircuitBreaker has opened the circuit for its endpoint.
+type ErrCircuitOpen struct {
+	BaseError
+	Endpoint string
+
+	// RetryAt is when the circuit will let a probe request through.
+	RetryAt time.Time
+}
+
+func (e ErrCircuitOpen) Error() string {
+	e.DefaultErrString = fmt.Sprintf("Circuit open for endpoint [%s] after repeated failures; retry after %s",
+		e.Endpoint, e.RetryAt.Format(time.RFC3339))
+	return e.choseErrString()
+}
+
+// ErrDuplicateKey is the error when a response body checked with
+// CheckDuplicateKeys repeats a key within a JSON object.
+type ErrDuplicateKey struct {
+	BaseError
+	Key string
+}
+
+func (e ErrDuplicateKey) Error() string {
+	e.DefaultErrString = fmt.Sprintf("Response contains key [%s] more than once in the same object", e.Key)
+	return e.choseErrString()
+}
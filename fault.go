@@ -0,0 +1,120 @@
+package gophercloud
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FaultDetail is the explanation an OpenStack service gives in the body of
+// an error response, as extracted by ExtractFault.
+type FaultDetail struct {
+	// Type is the key of the envelope the fault was found in, e.g.
+	// "badRequest" or "itemNotFound" for the Block Storage service. It is
+	// empty for services, such as the Image service, that do not wrap their
+	// faults.
+	Type string
+
+	// Code is the status code given in the fault, or the status code of the
+	// response if the fault gives none.
+	Code int
+
+	// Message is the reason the service gives for the error.
+	Message string
+}
+
+// responseCoder is implemented by ErrUnexpectedResponseCode and by every
+// error that embeds it, such as ErrDefault400.
+type responseCoder interface {
+	unexpectedResponseCode() ErrUnexpectedResponseCode
+}
+
+func (e ErrUnexpectedResponseCode) unexpectedResponseCode() ErrUnexpectedResponseCode {
+	return e
+}
+
+// ExtractFault extracts the fault an OpenStack service described in the body
+// of the error response behind err, so that the reason for the error can be
+// shown rather than just its status code. It reports false if err is not
+// caused by an error response, or if the body holds no fault in one of the
+// known shapes:
+//
+//	{"badRequest": {"message": "...", "code": 400}}
+//	{"error": {"message": "...", "code": 400, "title": "Bad Request"}}
+//	{"message": "...", "code": "400 Bad Request", "title": "Bad Request"}
+//
+// The first is used by the Block Storage and Compute services, under a key
+// naming the kind of fault, the second by the Identity service, and the last
+// by the Image service. Errors after reauthentication are looked through.
+func ExtractFault(err error) (FaultDetail, bool) {
+	switch e := err.(type) {
+	case *ErrErrorAfterReauthentication:
+		return ExtractFault(e.ErrOriginal)
+	case ErrErrorAfterReauthentication:
+		return ExtractFault(e.ErrOriginal)
+	case responseCoder:
+		return parseFault(e.unexpectedResponseCode())
+	}
+	return FaultDetail{}, false
+}
+
+// rawFault is a fault as found in an error response body.
+type rawFault struct {
+	Message *string     `json:"message"`
+	Code    interface{} `json:"code"`
+}
+
+func parseFault(e ErrUnexpectedResponseCode) (FaultDetail, bool) {
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(e.Body, &body); err != nil {
+		return FaultDetail{}, false
+	}
+
+	// An unwrapped fault.
+	var f rawFault
+	if _, ok := body["message"]; ok {
+		if err := json.Unmarshal(e.Body, &f); err == nil && f.Message != nil {
+			return newFaultDetail("", f, e.Actual), true
+		}
+	}
+
+	// A fault wrapped in an envelope. Try the keys in order, so that the
+	// result does not depend on map iteration if there are several.
+	keys := make([]string, 0, len(body))
+	for k := range body {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		var f rawFault
+		if err := json.Unmarshal(body[k], &f); err == nil && f.Message != nil {
+			return newFaultDetail(k, f, e.Actual), true
+		}
+	}
+
+	return FaultDetail{}, false
+}
+
+func newFaultDetail(kind string, f rawFault, status int) FaultDetail {
+	fd := FaultDetail{
+		Type: kind,
+		Code: status,
+		// The Image service formats its messages for HTML.
+		Message: strings.TrimSpace(strings.Replace(*f.Message, "<br />", " ", -1)),
+	}
+
+	switch code := f.Code.(type) {
+	case float64:
+		fd.Code = int(code)
+	case string:
+		// E.g. "400 Bad Request".
+		if fields := strings.Fields(code); len(fields) > 0 {
+			if c, err := strconv.Atoi(fields[0]); err == nil {
+				fd.Code = c
+			}
+		}
+	}
+
+	return fd
+}
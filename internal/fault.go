@@ -0,0 +1,23 @@
+package internal
+
+import (
+	"regexp"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+// volumeInUseMessage matches the message the Block Storage service gives when
+// it refuses an operation because the volume is attached, e.g. "Invalid
+// volume: Volume 1234 status must be available, but current status is:
+// in-use."
+var volumeInUseMessage = regexp.MustCompile(`^Invalid volume: .*status is:? in-use\.?$`)
+
+// IsVolumeInUse reports whether err is a 400 from the Block Storage service
+// refusing an operation because the volume is attached ("in-use").
+func IsVolumeInUse(err error) bool {
+	if _, ok := err.(gophercloud.ErrDefault400); !ok {
+		return false
+	}
+	fault, ok := gophercloud.ExtractFault(err)
+	return ok && volumeInUseMessage.MatchString(fault.Message)
+}
@@ -0,0 +1,33 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/internal"
+)
+
+func TestIsVolumeInUse(t *testing.T) {
+	cases := []struct {
+		body     string
+		expected bool
+	}{
+		{`{"badRequest": {"code": 400, "message": "Invalid volume: Volume 1234 status must be available, but current status is: in-use."}}`, true},
+		{`{"badRequest": {"code": 400, "message": "Invalid volume: Volume status is in-use."}}`, true},
+		{`{"badRequest": {"code": 400, "message": "Invalid volume: Volume 1234 status must be available, but current status is: error."}}`, false},
+		{`{"badRequest": {"code": 400, "message": "Invalid input received: Metadata key 'in-use' is reserved."}}`, false},
+		{`in-use`, false},
+	}
+
+	for _, c := range cases {
+		err := gophercloud.ErrDefault400{ErrUnexpectedResponseCode: gophercloud.ErrUnexpectedResponseCode{Actual: 400, Body: []byte(c.body)}}
+		if actual := internal.IsVolumeInUse(err); actual != c.expected {
+			t.Errorf("Expected %t for %s, got %t", c.expected, c.body, actual)
+		}
+	}
+
+	err := gophercloud.ErrDefault404{ErrUnexpectedResponseCode: gophercloud.ErrUnexpectedResponseCode{Actual: 404, Body: []byte(cases[0].body)}}
+	if internal.IsVolumeInUse(err) {
+		t.Errorf("Expected a 404 not to be an in-use fault")
+	}
+}
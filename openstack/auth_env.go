@@ -23,6 +23,12 @@ OS_TENANT_ID and OS_TENANT_NAME are mutually exclusive to OS_PROJECT_ID and
 OS_PROJECT_NAME. If OS_PROJECT_ID and OS_PROJECT_NAME are set, they will
 still be referred as "tenant" in Gophercloud.
 
+To authenticate with an application credential instead, set
+OS_APPLICATION_CREDENTIAL_SECRET and either OS_APPLICATION_CREDENTIAL_ID, or
+OS_APPLICATION_CREDENTIAL_NAME and the user. OS_PASSWORD is then not needed,
+nor is the user with OS_APPLICATION_CREDENTIAL_ID, and the tenant and project
+variables are ignored, since the application credential implies the project.
+
 To use this function, first set the OS_* environment variables (for example,
 by sourcing an `openrc` file), then:
 
@@ -38,6 +44,9 @@ func AuthOptionsFromEnv() (gophercloud.AuthOptions, error) {
 	tenantName := os.Getenv("OS_TENANT_NAME")
 	domainID := os.Getenv("OS_DOMAIN_ID")
 	domainName := os.Getenv("OS_DOMAIN_NAME")
+	applicationCredentialID := os.Getenv("OS_APPLICATION_CREDENTIAL_ID")
+	applicationCredentialName := os.Getenv("OS_APPLICATION_CREDENTIAL_NAME")
+	applicationCredentialSecret := os.Getenv("OS_APPLICATION_CREDENTIAL_SECRET")
 
 	// If OS_PROJECT_ID is set, overwrite tenantID with the value.
 	if v := os.Getenv("OS_PROJECT_ID"); v != "" {
@@ -56,6 +65,35 @@ func AuthOptionsFromEnv() (gophercloud.AuthOptions, error) {
 		return nilOptions, err
 	}
 
+	if applicationCredentialID != "" || applicationCredentialName != "" {
+		if applicationCredentialSecret == "" {
+			err := gophercloud.ErrMissingEnvironmentVariable{
+				EnvironmentVariable: "OS_APPLICATION_CREDENTIAL_SECRET",
+			}
+			return nilOptions, err
+		}
+		if applicationCredentialID == "" && username == "" && userID == "" {
+			err := gophercloud.ErrMissingAnyoneOfEnvironmentVariables{
+				EnvironmentVariables: []string{"OS_USERNAME", "OS_USERID"},
+			}
+			return nilOptions, err
+		}
+
+		ao := gophercloud.AuthOptions{
+			IdentityEndpoint:            authURL,
+			ApplicationCredentialID:     applicationCredentialID,
+			ApplicationCredentialName:   applicationCredentialName,
+			ApplicationCredentialSecret: applicationCredentialSecret,
+		}
+		if applicationCredentialID == "" {
+			ao.UserID = userID
+			ao.Username = username
+			ao.DomainID = domainID
+			ao.DomainName = domainName
+		}
+		return ao, nil
+	}
+
 	if username == "" && userID == "" {
 		err := gophercloud.ErrMissingAnyoneOfEnvironmentVariables{
 			EnvironmentVariables: []string{"OS_USERNAME", "OS_USERID"},
@@ -0,0 +1,31 @@
+/*
+Package apiversions provides information about the API versions advertised by
+the OpenStack Block Storage service, code-named Cinder, and the microversion
+range of each. The versions are listed from the root of the service's
+endpoint, so this functionality is not restricted to a particular version.
+
+Example to List API Versions
+
+	allPages, err := apiversions.List(client).AllPages()
+	if err != nil {
+		panic(err)
+	}
+
+	allVersions, err := apiversions.ExtractAPIVersions(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, version := range allVersions {
+		fmt.Printf("%+v\n", version)
+	}
+
+Example to Check that a Microversion is Supported
+
+	for _, version := range allVersions {
+		if version.Status == apiversions.StatusCurrent && version.SupportsMicroversion("3.44") {
+			client.Microversion = "3.44"
+		}
+	}
+*/
+package apiversions
@@ -0,0 +1,103 @@
+package apiversions
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+// Version statuses advertised by the Block Storage service.
+const (
+	StatusCurrent    = "CURRENT"
+	StatusSupported  = "SUPPORTED"
+	StatusDeprecated = "DEPRECATED"
+)
+
+// APIVersion represents an API version for the Block Storage service.
+type APIVersion struct {
+	// ID is the unique identifier of the API version, e.g. "v3.0".
+	ID string `json:"id"`
+
+	// Status is the status of the API version, e.g. StatusCurrent.
+	Status string `json:"status"`
+
+	// Updated is the date when the API was last updated.
+	Updated time.Time `json:"updated"`
+
+	// MinVersion is the minimum microversion supported. It is empty if the
+	// API version does not support microversions.
+	MinVersion string `json:"min_version"`
+
+	// Version is the maximum microversion supported. It is empty if the API
+	// version does not support microversions.
+	Version string `json:"version"`
+}
+
+// SupportsMicroversion reports whether microversion, in "major.minor" form,
+// lies within the range of microversions the API version supports.
+func (r APIVersion) SupportsMicroversion(microversion string) bool {
+	v, ok := parseMicroversion(microversion)
+	if !ok {
+		return false
+	}
+	min, ok := parseMicroversion(r.MinVersion)
+	if !ok {
+		return false
+	}
+	max, ok := parseMicroversion(r.Version)
+	if !ok {
+		return false
+	}
+	return !v.less(min) && !max.less(v)
+}
+
+type microversion struct {
+	major, minor int
+}
+
+func (v microversion) less(o microversion) bool {
+	if v.major != o.major {
+		return v.major < o.major
+	}
+	return v.minor < o.minor
+}
+
+func parseMicroversion(s string) (microversion, bool) {
+	parts := strings.SplitN(s, ".", 2)
+	if len(parts) != 2 {
+		return microversion{}, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return microversion{}, false
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return microversion{}, false
+	}
+	return microversion{major, minor}, true
+}
+
+// APIVersionPage is the page returned by a pager when traversing over a
+// collection of API versions.
+type APIVersionPage struct {
+	pagination.SinglePageBase
+}
+
+// IsEmpty checks whether an APIVersionPage struct is empty.
+func (r APIVersionPage) IsEmpty() (bool, error) {
+	is, err := ExtractAPIVersions(r)
+	return len(is) == 0, err
+}
+
+// ExtractAPIVersions takes a collection page, extracts all of the elements,
+// and returns them a slice of APIVersion structs. It is effectively a cast.
+func ExtractAPIVersions(r pagination.Page) ([]APIVersion, error) {
+	var s struct {
+		Versions []APIVersion `json:"versions"`
+	}
+	err := (r.(APIVersionPage)).ExtractInto(&s)
+	return s.Versions, err
+}
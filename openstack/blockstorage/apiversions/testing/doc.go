@@ -0,0 +1,2 @@
+// apiversions_v3
+package testing
@@ -0,0 +1,79 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/apiversions"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+const APIListResponse = `
+{
+    "versions": [
+        {
+            "id": "v2.0",
+            "links": [
+                {
+                    "href": "http://localhost:8776/v2/",
+                    "rel": "self"
+                }
+            ],
+            "min_version": "",
+            "status": "DEPRECATED",
+            "updated": "2017-02-25T12:00:00Z",
+            "version": ""
+        },
+        {
+            "id": "v3.0",
+            "links": [
+                {
+                    "href": "http://localhost:8776/v3/",
+                    "rel": "self"
+                }
+            ],
+            "media-types": [
+                {
+                    "base": "application/json",
+                    "type": "application/vnd.openstack.volume+json;version=3"
+                }
+            ],
+            "min_version": "3.0",
+            "status": "CURRENT",
+            "updated": "2018-07-17T00:00:00Z",
+            "version": "3.59"
+        }
+    ]
+}
+`
+
+var APIVersion2Result = apiversions.APIVersion{
+	ID:      "v2.0",
+	Status:  apiversions.StatusDeprecated,
+	Updated: time.Date(2017, 2, 25, 12, 0, 0, 0, time.UTC),
+}
+
+var APIVersion3Result = apiversions.APIVersion{
+	ID:         "v3.0",
+	Status:     apiversions.StatusCurrent,
+	Updated:    time.Date(2018, 7, 17, 0, 0, 0, 0, time.UTC),
+	MinVersion: "3.0",
+	Version:    "3.59",
+}
+
+// MockListResponse serves the version list the way Cinder does, with a 300
+// Multiple Choices status.
+func MockListResponse(t *testing.T) {
+	th.Mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMultipleChoices)
+
+		fmt.Fprintf(w, APIListResponse)
+	})
+}
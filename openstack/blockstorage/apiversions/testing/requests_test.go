@@ -0,0 +1,42 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/apiversions"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+func TestListAPIVersions(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockListResponse(t)
+
+	allPages, err := apiversions.List(client.ServiceClient()).AllPages()
+	th.AssertNoErr(t, err)
+
+	actual, err := apiversions.ExtractAPIVersions(allPages)
+	th.AssertNoErr(t, err)
+
+	th.AssertDeepEquals(t, []apiversions.APIVersion{APIVersion2Result, APIVersion3Result}, actual)
+}
+
+func TestSupportsMicroversion(t *testing.T) {
+	cases := map[string]bool{
+		"3.0":  true,
+		"3.44": true,
+		"3.59": true,
+		"3.60": false,
+		"2.9":  false,
+		"4.0":  false,
+		"3":    false,
+		"":     false,
+	}
+	for mv, expected := range cases {
+		th.AssertEquals(t, expected, APIVersion3Result.SupportsMicroversion(mv))
+	}
+
+	th.AssertEquals(t, false, APIVersion2Result.SupportsMicroversion("2.0"))
+}
@@ -0,0 +1,60 @@
+/*
+Package backups provides information and interaction with the volume backups
+API of the OpenStack Block Storage service. A backup is a full or incremental
+copy of a volume, kept by the backup service outside of the volume's backend,
+that can be restored to a new or an existing volume.
+
+Example to Create a Backup
+
+	createOpts := backups.CreateOpts{
+		VolumeID:    "289da7f8-6440-407c-9fb4-7db01ec49164",
+		Name:        "nightly",
+		Incremental: true,
+	}
+
+	backup, err := backups.Create(client, createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to List the Backups of a Volume
+
+	listOpts := backups.ListOpts{
+		VolumeID: "289da7f8-6440-407c-9fb4-7db01ec49164",
+	}
+
+	allPages, err := backups.List(client, listOpts).AllPages()
+	if err != nil {
+		panic(err)
+	}
+
+	allBackups, err := backups.ExtractBackups(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, backup := range allBackups {
+		if !backup.HasDependentBackups {
+			fmt.Printf("Backup %s can be deleted\n", backup.ID)
+		}
+	}
+
+Example to Restore a Backup to a New Volume
+
+	restoreOpts := backups.RestoreOpts{
+		Name: "restored",
+	}
+
+	restore, err := backups.RestoreFromBackup(client, backupID, restoreOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Delete a Backup
+
+	err := backups.Delete(client, backupID).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+*/
+package backups
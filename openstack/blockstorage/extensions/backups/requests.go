@@ -0,0 +1,175 @@
+package backups
+
+import (
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+// CreateOptsBuilder allows extensions to add additional parameters to the
+// Create request.
+type CreateOptsBuilder interface {
+	ToBackupCreateMap() (map[string]interface{}, error)
+}
+
+// CreateOpts contains options for creating a Backup. This object is passed to
+// the backups.Create function.
+type CreateOpts struct {
+	// VolumeID is the ID of the volume to back up.
+	VolumeID string `json:"volume_id" required:"true"`
+
+	// Name is the name of the backup.
+	Name string `json:"name,omitempty"`
+
+	// Description is the description of the backup.
+	Description string `json:"description,omitempty"`
+
+	// Container is the container the backup is stored in, for backup drivers
+	// that use containers, such as Swift.
+	Container string `json:"container,omitempty"`
+
+	// Incremental creates an incremental backup, holding only the changes
+	// since the latest backup of the volume. A full backup is created if it
+	// is not set.
+	Incremental bool `json:"incremental,omitempty"`
+
+	// Force backs up the volume even if it is attached ("in-use").
+	Force bool `json:"force,omitempty"`
+
+	// SnapshotID is the ID of a snapshot of the volume to back up instead of
+	// the volume itself.
+	SnapshotID string `json:"snapshot_id,omitempty"`
+}
+
+// ToBackupCreateMap assembles a request body based on the contents of a
+// CreateOpts.
+func (opts CreateOpts) ToBackupCreateMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "backup")
+}
+
+// Create will create a new Backup based on the values in CreateOpts. The
+// backup is created asynchronously: the response only holds its ID and name,
+// and it is "creating" until it becomes "available". To extract the Backup
+// object from the response, call the Extract method on the CreateResult.
+func Create(client *gophercloud.ServiceClient, opts CreateOptsBuilder) (r CreateResult) {
+	b, err := opts.ToBackupCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = client.Post(createURL(client), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{202},
+	})
+	return
+}
+
+// Get retrieves the Backup with the provided ID. To extract the Backup object
+// from the response, call the Extract method on the GetResult.
+func Get(client *gophercloud.ServiceClient, id string) (r GetResult) {
+	_, r.Err = client.Get(getURL(client, id), &r.Body, nil)
+	return
+}
+
+// ListOptsBuilder allows extensions to add additional parameters to the List
+// request.
+type ListOptsBuilder interface {
+	ToBackupListQuery() (string, error)
+}
+
+// ListOpts holds options for listing Backups. It is passed to the
+// backups.List function.
+type ListOpts struct {
+	// AllTenants will retrieve backups of all tenants/projects.
+	AllTenants bool `q:"all_tenants"`
+
+	// Name will filter by the specified backup name.
+	Name string `q:"name"`
+
+	// Status will filter by the specified status.
+	Status string `q:"status"`
+
+	// VolumeID will filter by the specified volume ID.
+	VolumeID string `q:"volume_id"`
+
+	// Comma-separated list of sort keys and optional sort directions in the
+	// form of <key>[:<direction>].
+	Sort string `q:"sort"`
+
+	// Requests a page size of items.
+	Limit int `q:"limit"`
+
+	// Used in conjunction with limit to return a slice of items.
+	Offset int `q:"offset"`
+
+	// The ID of the last-seen item.
+	Marker string `q:"marker"`
+}
+
+// ToBackupListQuery formats a ListOpts into a query string.
+func (opts ListOpts) ToBackupListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	return q.String(), err
+}
+
+// List returns Backups optionally limited by the conditions provided in
+// ListOpts.
+func List(client *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pager {
+	url := listURL(client)
+	if opts != nil {
+		query, err := opts.ToBackupListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return BackupPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// Delete will delete the existing Backup with the provided ID. A backup that
+// other incremental backups depend on cannot be deleted until they are; see
+// Backup.HasDependentBackups.
+func Delete(client *gophercloud.ServiceClient, id string) (r DeleteResult) {
+	_, r.Err = client.Delete(deleteURL(client, id), nil)
+	return
+}
+
+// RestoreOptsBuilder allows extensions to add additional parameters to the
+// RestoreFromBackup request.
+type RestoreOptsBuilder interface {
+	ToRestoreMap() (map[string]interface{}, error)
+}
+
+// RestoreOpts contains options for restoring a Backup. This object is passed
+// to the backups.RestoreFromBackup function.
+type RestoreOpts struct {
+	// VolumeID is the ID of the volume to restore the backup to. It is
+	// overwritten. If it is not set, a new volume is created.
+	VolumeID string `json:"volume_id,omitempty"`
+
+	// Name is the name of the new volume. It is ignored if VolumeID is set.
+	Name string `json:"name,omitempty"`
+}
+
+// ToRestoreMap assembles a request body based on the contents of a
+// RestoreOpts.
+func (opts RestoreOpts) ToRestoreMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "restore")
+}
+
+// RestoreFromBackup restores the Backup with the provided ID to a volume. The
+// restore is asynchronous: the volume is "restoring-backup" until it is done.
+// To extract the Restore object from the response, call the Extract method on
+// the RestoreResult.
+func RestoreFromBackup(client *gophercloud.ServiceClient, id string, opts RestoreOptsBuilder) (r RestoreResult) {
+	b, err := opts.ToRestoreMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = client.Post(restoreURL(client, id), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{202},
+	})
+	return
+}
@@ -0,0 +1,172 @@
+package backups
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+// Backup contains all the information associated with a Cinder volume
+// backup.
+type Backup struct {
+	// ID is the unique identifier of the backup.
+	ID string `json:"id"`
+
+	// CreatedAt is the date the backup was created.
+	CreatedAt time.Time `json:"-"`
+
+	// UpdatedAt is the date the backup was last updated.
+	UpdatedAt time.Time `json:"-"`
+
+	// Name is the display name of the backup.
+	Name string `json:"name"`
+
+	// Description is the description of the backup.
+	Description string `json:"description"`
+
+	// VolumeID is the ID of the backed up volume.
+	VolumeID string `json:"volume_id"`
+
+	// SnapshotID is the ID of the snapshot the backup was created from, if
+	// any.
+	SnapshotID string `json:"snapshot_id"`
+
+	// Status is the current status of the backup, e.g. "creating",
+	// "available", "restoring", "deleting" or "error".
+	Status string `json:"status"`
+
+	// Size is the size of the backed up volume, in GB.
+	Size int `json:"size"`
+
+	// ObjectCount is the number of objects the backup is stored in.
+	ObjectCount int `json:"object_count"`
+
+	// Container is the container the backup is stored in.
+	Container string `json:"container"`
+
+	// AvailabilityZone is the availability zone of the backup.
+	AvailabilityZone string `json:"availability_zone"`
+
+	// FailReason is the reason the backup failed, if its status is "error".
+	FailReason string `json:"fail_reason"`
+
+	// IsIncremental is whether the backup only holds the changes since the
+	// backup it is based on.
+	IsIncremental bool `json:"is_incremental"`
+
+	// HasDependentBackups is whether incremental backups are based on this
+	// backup. Such a backup cannot be deleted until they are.
+	HasDependentBackups bool `json:"has_dependent_backups"`
+}
+
+// UnmarshalJSON converts our JSON API response into our backup struct
+func (r *Backup) UnmarshalJSON(b []byte) error {
+	type tmp Backup
+	var s struct {
+		tmp
+		CreatedAt gophercloud.JSONRFC3339MilliNoZ `json:"created_at"`
+		UpdatedAt gophercloud.JSONRFC3339MilliNoZ `json:"updated_at"`
+	}
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+	*r = Backup(s.tmp)
+
+	r.CreatedAt = time.Time(s.CreatedAt)
+	r.UpdatedAt = time.Time(s.UpdatedAt)
+
+	return err
+}
+
+// BackupPage is a pagination.Pager that is returned from a call to the List
+// function.
+type BackupPage struct {
+	pagination.LinkedPageBase
+}
+
+// IsEmpty returns true if a BackupPage contains no Backups.
+func (r BackupPage) IsEmpty() (bool, error) {
+	backups, err := ExtractBackups(r)
+	return len(backups) == 0, err
+}
+
+// NextPageURL uses the response's embedded link reference to navigate to the
+// next page of results.
+func (r BackupPage) NextPageURL() (string, error) {
+	var s struct {
+		Links []gophercloud.Link `json:"backups_links"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return gophercloud.ExtractNextURL(s.Links)
+}
+
+// ExtractBackups extracts and returns Backups. It is used while iterating over
+// a backups.List call.
+func ExtractBackups(r pagination.Page) ([]Backup, error) {
+	var s struct {
+		Backups []Backup `json:"backups"`
+	}
+	err := (r.(BackupPage)).ExtractInto(&s)
+	return s.Backups, err
+}
+
+type commonResult struct {
+	gophercloud.Result
+}
+
+// Extract will get the Backup object out of the commonResult object.
+func (r commonResult) Extract() (*Backup, error) {
+	var s struct {
+		Backup *Backup `json:"backup"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Backup, err
+}
+
+// CreateResult contains the response body and error from a Create request.
+type CreateResult struct {
+	commonResult
+}
+
+// GetResult contains the response body and error from a Get request.
+type GetResult struct {
+	commonResult
+}
+
+// DeleteResult contains the response body and error from a Delete request.
+type DeleteResult struct {
+	gophercloud.ErrResult
+}
+
+// Restore contains the information returned by a RestoreFromBackup request.
+type Restore struct {
+	// BackupID is the ID of the restored backup.
+	BackupID string `json:"backup_id"`
+
+	// VolumeID is the ID of the volume the backup is restored to.
+	VolumeID string `json:"volume_id"`
+
+	// VolumeName is the name of the volume the backup is restored to.
+	VolumeName string `json:"volume_name"`
+}
+
+// RestoreResult contains the response body and error from a
+// RestoreFromBackup request.
+type RestoreResult struct {
+	gophercloud.Result
+}
+
+// Extract will get the Restore object out of the RestoreResult object.
+func (r RestoreResult) Extract() (*Restore, error) {
+	var s struct {
+		Restore *Restore `json:"restore"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Restore, err
+}
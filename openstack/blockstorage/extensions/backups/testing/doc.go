@@ -0,0 +1,2 @@
+// backups unit tests
+package testing
@@ -0,0 +1,137 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/testhelper"
+	fake "github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+const backupBody = `
+{
+  "backup": {
+    "id": "6008ea9a-1e4b-4f0b-b4c7-a6d5d2e3a3e1",
+    "name": "nightly",
+    "description": "",
+    "volume_id": "289da7f8-6440-407c-9fb4-7db01ec49164",
+    "snapshot_id": null,
+    "status": "available",
+    "size": 10,
+    "object_count": 22,
+    "container": "volumebackups",
+    "availability_zone": "nova",
+    "fail_reason": null,
+    "is_incremental": false,
+    "has_dependent_backups": true,
+    "created_at": "2018-04-05T09:45:23.000000",
+    "updated_at": "2018-04-05T09:48:11.000000"
+  }
+}
+`
+
+func MockCreateResponse(t *testing.T) {
+	th.Mux.HandleFunc("/backups", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestJSONRequest(t, r, `
+{
+  "backup": {
+    "volume_id": "289da7f8-6440-407c-9fb4-7db01ec49164",
+    "name": "incremental",
+    "incremental": true,
+    "force": true
+  }
+}
+`)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, `
+{
+  "backup": {
+    "id": "a1f3e8c4-5b2d-4e6a-9c7b-0d8e9f1a2b3c",
+    "name": "incremental",
+    "links": []
+  }
+}
+`)
+	})
+}
+
+func MockGetResponse(t *testing.T) {
+	th.Mux.HandleFunc("/backups/6008ea9a-1e4b-4f0b-b4c7-a6d5d2e3a3e1", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, backupBody)
+	})
+}
+
+func MockListResponse(t *testing.T) {
+	th.Mux.HandleFunc("/backups/detail", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{"volume_id": "289da7f8-6440-407c-9fb4-7db01ec49164"})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `
+{
+  "backups": [
+    {
+      "id": "6008ea9a-1e4b-4f0b-b4c7-a6d5d2e3a3e1",
+      "name": "nightly",
+      "volume_id": "289da7f8-6440-407c-9fb4-7db01ec49164",
+      "status": "available",
+      "size": 10,
+      "is_incremental": false,
+      "has_dependent_backups": true,
+      "created_at": "2018-04-05T09:45:23.000000"
+    },
+    {
+      "id": "a1f3e8c4-5b2d-4e6a-9c7b-0d8e9f1a2b3c",
+      "name": "incremental",
+      "volume_id": "289da7f8-6440-407c-9fb4-7db01ec49164",
+      "status": "available",
+      "size": 10,
+      "is_incremental": true,
+      "has_dependent_backups": false,
+      "created_at": "2018-04-06T09:45:23.000000"
+    }
+  ]
+}
+`)
+	})
+}
+
+func MockDeleteResponse(t *testing.T) {
+	th.Mux.HandleFunc("/backups/6008ea9a-1e4b-4f0b-b4c7-a6d5d2e3a3e1", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+func MockRestoreResponse(t *testing.T) {
+	th.Mux.HandleFunc("/backups/6008ea9a-1e4b-4f0b-b4c7-a6d5d2e3a3e1/restore", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestJSONRequest(t, r, `{"restore": {"name": "restored"}}`)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, `
+{
+  "restore": {
+    "backup_id": "6008ea9a-1e4b-4f0b-b4c7-a6d5d2e3a3e1",
+    "volume_id": "795114e8-7489-40be-a978-83797f2c1dd3",
+    "volume_name": "restored"
+  }
+}
+`)
+	})
+}
@@ -0,0 +1,103 @@
+package testing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/backups"
+	"github.com/gophercloud/gophercloud/pagination"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+func TestCreate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockCreateResponse(t)
+
+	options := backups.CreateOpts{
+		VolumeID:    "289da7f8-6440-407c-9fb4-7db01ec49164",
+		Name:        "incremental",
+		Incremental: true,
+		Force:       true,
+	}
+	b, err := backups.Create(client.ServiceClient(), options).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "a1f3e8c4-5b2d-4e6a-9c7b-0d8e9f1a2b3c", b.ID)
+	th.AssertEquals(t, "incremental", b.Name)
+}
+
+func TestGet(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockGetResponse(t)
+
+	b, err := backups.Get(client.ServiceClient(), "6008ea9a-1e4b-4f0b-b4c7-a6d5d2e3a3e1").Extract()
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, backups.Backup{
+		ID:                  "6008ea9a-1e4b-4f0b-b4c7-a6d5d2e3a3e1",
+		CreatedAt:           time.Date(2018, 4, 5, 9, 45, 23, 0, time.UTC),
+		UpdatedAt:           time.Date(2018, 4, 5, 9, 48, 11, 0, time.UTC),
+		Name:                "nightly",
+		VolumeID:            "289da7f8-6440-407c-9fb4-7db01ec49164",
+		Status:              "available",
+		Size:                10,
+		ObjectCount:         22,
+		Container:           "volumebackups",
+		AvailabilityZone:    "nova",
+		HasDependentBackups: true,
+	}, *b)
+}
+
+func TestList(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockListResponse(t)
+
+	count := 0
+	err := backups.List(client.ServiceClient(), backups.ListOpts{VolumeID: "289da7f8-6440-407c-9fb4-7db01ec49164"}).EachPage(func(page pagination.Page) (bool, error) {
+		count++
+		actual, err := backups.ExtractBackups(page)
+		if err != nil {
+			return false, err
+		}
+
+		th.AssertEquals(t, 2, len(actual))
+		th.AssertEquals(t, false, actual[0].IsIncremental)
+		th.AssertEquals(t, true, actual[0].HasDependentBackups)
+		th.AssertEquals(t, true, actual[1].IsIncremental)
+		th.AssertEquals(t, false, actual[1].HasDependentBackups)
+		th.AssertEquals(t, time.Date(2018, 4, 6, 9, 45, 23, 0, time.UTC), actual[1].CreatedAt)
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, count)
+}
+
+func TestDelete(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockDeleteResponse(t)
+
+	err := backups.Delete(client.ServiceClient(), "6008ea9a-1e4b-4f0b-b4c7-a6d5d2e3a3e1").ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestRestoreFromBackup(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockRestoreResponse(t)
+
+	restore, err := backups.RestoreFromBackup(client.ServiceClient(), "6008ea9a-1e4b-4f0b-b4c7-a6d5d2e3a3e1", backups.RestoreOpts{Name: "restored"}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, backups.Restore{
+		BackupID:   "6008ea9a-1e4b-4f0b-b4c7-a6d5d2e3a3e1",
+		VolumeID:   "795114e8-7489-40be-a978-83797f2c1dd3",
+		VolumeName: "restored",
+	}, *restore)
+}
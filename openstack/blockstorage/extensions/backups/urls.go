@@ -0,0 +1,23 @@
+package backups
+
+import "github.com/gophercloud/gophercloud"
+
+func createURL(c *gophercloud.ServiceClient) string {
+	return c.ServiceURL("backups")
+}
+
+func listURL(c *gophercloud.ServiceClient) string {
+	return c.ServiceURL("backups", "detail")
+}
+
+func getURL(c *gophercloud.ServiceClient, id string) string {
+	return c.ServiceURL("backups", id)
+}
+
+func deleteURL(c *gophercloud.ServiceClient, id string) string {
+	return getURL(c, id)
+}
+
+func restoreURL(c *gophercloud.ServiceClient, id string) string {
+	return c.ServiceURL("backups", id, "restore")
+}
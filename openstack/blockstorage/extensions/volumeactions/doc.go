@@ -51,6 +51,104 @@ Example of Extending a Volume's Size
 		panic(err)
 	}
 
+	err = volumes.WaitForExtend(client, volume.ID, extendOpts.NewSize, 60)
+	if err != nil {
+		panic(err)
+	}
+
+Example of Changing a Volume's Type
+
+	retypeOpts := volumeactions.RetypeOpts{
+		NewType:         "ssd",
+		MigrationPolicy: volumeactions.MigrationPolicyOnDemand,
+	}
+
+	err := volumeactions.Retype(client, volume.ID, retypeOpts).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
+Example of Migrating a Volume to Another Backend
+
+	migrateOpts := volumeactions.MigrateOpts{
+		Host:       "cinder-2@lvm#lvm",
+		LockVolume: true,
+	}
+
+	err := volumeactions.Migrate(client, volume.ID, migrateOpts).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
+	err = volumes.WaitForMigration(client, volume.ID, migrateOpts.Host, 3600)
+	if err != nil {
+		panic(err)
+	}
+
+Example of Resetting a Volume's Attach Status
+
+	resetOpts := volumeactions.ResetStatusOpts{
+		AttachStatus: "detached",
+	}
+
+	err := volumeactions.ResetStatus(client, volume.ID, resetOpts).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
+Example of Marking a Volume Bootable
+
+	setBootableOpts := volumeactions.SetBootableOpts{
+		Bootable: true,
+	}
+
+	err := volumeactions.SetBootable(client, volume.ID, setBootableOpts).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
+	// The flag is reported back as a string.
+	volume, err = volumes.Get(client, volume.ID).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("Bootable: %t\n", volume.Bootable == "true")
+
+Example of Marking a Volume Read-Only
+
+	setReadOnlyOpts := volumeactions.SetReadOnlyOpts{
+		ReadOnly: true,
+	}
+
+	err := volumeactions.SetReadOnly(client, volume.ID, setReadOnlyOpts).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
+Example of Unsticking Volumes
+
+	stuck, err := volumes.ListStuck(client, volumes.ListStuckOpts{MinAge: time.Hour})
+	if err != nil {
+		panic(err)
+	}
+
+	for _, v := range stuck {
+		resetOpts := volumeactions.ResetStatusOpts{
+			Status: v.ResetStatus,
+		}
+
+		err := volumeactions.ResetStatus(client, v.Volume.ID, resetOpts).ExtractErr()
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	err = volumeactions.ForceDelete(client, volume.ID).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
 Example of Initializing a Volume Connection
 
 	connectOpts := &volumeactions.InitializeConnectionOpts{
@@ -82,5 +180,14 @@ Example of Initializing a Volume Connection
 	if err != nil {
 		panic(err)
 	}
+
+Example of Showing the Source Image Metadata of a Volume
+
+	imageMetadata, err := volumeactions.ShowImageMetadata(client, volume.ID).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("Created from image %s (%s)\n", imageMetadata.ImageName, imageMetadata.ImageID)
 */
 package volumeactions
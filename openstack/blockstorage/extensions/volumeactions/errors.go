@@ -0,0 +1,18 @@
+package volumeactions
+
+import (
+	"fmt"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+// ErrVolumeInUse is the error when an image of an attached volume is
+// requested without UploadImageOpts.Force.
+type ErrVolumeInUse struct {
+	gophercloud.ErrUnexpectedResponseCode
+	VolumeID string
+}
+
+func (e ErrVolumeInUse) Error() string {
+	return fmt.Sprintf("Volume [%s] is in use; set Force to upload an attached volume", e.VolumeID)
+}
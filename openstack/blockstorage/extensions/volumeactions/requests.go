@@ -2,6 +2,7 @@ package volumeactions
 
 import (
 	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/internal"
 )
 
 // AttachOptsBuilder allows extensions to add additional parameters to the
@@ -37,10 +38,25 @@ type AttachOpts struct {
 // ToVolumeAttachMap assembles a request body based on the contents of a
 // AttachOpts.
 func (opts AttachOpts) ToVolumeAttachMap() (map[string]interface{}, error) {
+	if opts.InstanceUUID == "" && opts.HostName == "" {
+		err := gophercloud.ErrMissingInput{}
+		err.Argument = "volumeactions.AttachOpts.InstanceUUID"
+		err.Info = "one of InstanceUUID and HostName must be set"
+		return nil, err
+	}
+	if opts.InstanceUUID != "" && opts.HostName != "" {
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "volumeactions.AttachOpts.HostName"
+		err.Value = opts.HostName
+		err.Info = "InstanceUUID and HostName cannot both be set"
+		return nil, err
+	}
 	return gophercloud.BuildRequestBody(opts, "os-attach")
 }
 
-// Attach will attach a volume based on the values in AttachOpts.
+// Attach will attach a volume based on the values in AttachOpts. It marks a
+// volume reserved with Reserve as attached, once the connection set up by
+// InitializeConnection is in use; it does not connect the volume itself.
 func Attach(client *gophercloud.ServiceClient, id string, opts AttachOptsBuilder) (r AttachResult) {
 	b, err := opts.ToVolumeAttachMap()
 	if err != nil {
@@ -205,11 +221,23 @@ type ExtendSizeOpts struct {
 // ToVolumeExtendSizeMap assembles a request body based on the contents of an
 // ExtendSizeOpts.
 func (opts ExtendSizeOpts) ToVolumeExtendSizeMap() (map[string]interface{}, error) {
+	if opts.NewSize < 0 {
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "volumeactions.ExtendSizeOpts.NewSize"
+		err.Value = opts.NewSize
+		err.Info = "NewSize must be a positive number of GB"
+		return nil, err
+	}
 	return gophercloud.BuildRequestBody(opts, "os-extend")
 }
 
 // ExtendSize will extend the size of the volume based on the provided information.
 // This operation does not return a response body.
+//
+// Extending is asynchronous: the volume moves to the "extending" status and
+// returns to "available" (or "in-use") with its new size once the backend has
+// finished. If the backend fails, the volume is left in "error_extending".
+// Use volumes.WaitForExtend to wait for the outcome.
 func ExtendSize(client *gophercloud.ServiceClient, id string, opts ExtendSizeOptsBuilder) (r ExtendSizeResult) {
 	b, err := opts.ToVolumeExtendSizeMap()
 	if err != nil {
@@ -222,6 +250,106 @@ func ExtendSize(client *gophercloud.ServiceClient, id string, opts ExtendSizeOpt
 	return
 }
 
+// MigrationPolicy describes whether a volume may be migrated to another
+// backend when it is retyped.
+type MigrationPolicy string
+
+const (
+	// MigrationPolicyNever fails the retype if the new type needs another
+	// backend.
+	MigrationPolicyNever MigrationPolicy = "never"
+
+	// MigrationPolicyOnDemand migrates the volume if the new type needs
+	// another backend.
+	MigrationPolicyOnDemand MigrationPolicy = "on-demand"
+)
+
+// RetypeOptsBuilder allows extensions to add additional parameters to the
+// Retype request.
+type RetypeOptsBuilder interface {
+	ToVolumeRetypeMap() (map[string]interface{}, error)
+}
+
+// RetypeOpts contains options for changing the type of an existing Volume.
+// This object is passed to the volumeactions.Retype function.
+type RetypeOpts struct {
+	// NewType is the name or ID of the new volume type.
+	NewType string `json:"new_type" required:"true"`
+
+	// MigrationPolicy is whether the volume may be migrated to another
+	// backend. The Block Storage service uses MigrationPolicyNever if it is
+	// not set.
+	MigrationPolicy MigrationPolicy `json:"migration_policy,omitempty"`
+}
+
+// ToVolumeRetypeMap assembles a request body based on the contents of a
+// RetypeOpts.
+func (opts RetypeOpts) ToVolumeRetypeMap() (map[string]interface{}, error) {
+	switch opts.MigrationPolicy {
+	case "", MigrationPolicyNever, MigrationPolicyOnDemand:
+	default:
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "volumeactions.RetypeOpts.MigrationPolicy"
+		err.Value = opts.MigrationPolicy
+		err.Info = "MigrationPolicy must be never or on-demand"
+		return nil, err
+	}
+	return gophercloud.BuildRequestBody(opts, "os-retype")
+}
+
+// Retype changes the type of the volume with the provided ID. This operation
+// does not return a response body.
+//
+// Retyping is asynchronous: the volume moves to the "retyping" status and
+// returns to its previous status once the backend, or the migration to
+// another backend, has finished.
+func Retype(client *gophercloud.ServiceClient, id string, opts RetypeOptsBuilder) (r RetypeResult) {
+	b, err := opts.ToVolumeRetypeMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = client.Post(actionURL(client, id), b, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{202},
+	})
+	return
+}
+
+// ExtendVolumeCompletionOptsBuilder allows extensions to add additional
+// parameters to the ExtendVolumeCompletion request.
+type ExtendVolumeCompletionOptsBuilder interface {
+	ToVolumeExtendVolumeCompletionMap() (map[string]interface{}, error)
+}
+
+// ExtendVolumeCompletionOpts contains options for completing an online
+// extend of an attached volume.
+type ExtendVolumeCompletionOpts struct {
+	// Error indicates that the compute service failed to extend the volume
+	// for the guest, in which case the extend is rolled back.
+	Error bool `json:"error"`
+}
+
+// ToVolumeExtendVolumeCompletionMap assembles a request body based on the
+// contents of an ExtendVolumeCompletionOpts.
+func (opts ExtendVolumeCompletionOpts) ToVolumeExtendVolumeCompletionMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "os-extend_volume_completion")
+}
+
+// ExtendVolumeCompletion completes an extend of an attached volume that the
+// backend left waiting for the compute service. It requires microversion
+// 3.71 or later.
+func ExtendVolumeCompletion(client *gophercloud.ServiceClient, id string, opts ExtendVolumeCompletionOptsBuilder) (r ExtendVolumeCompletionResult) {
+	b, err := opts.ToVolumeExtendVolumeCompletionMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = client.Post(actionURL(client, id), b, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{202},
+	})
+	return
+}
+
 // UploadImageOptsBuilder allows extensions to add additional parameters to the
 // UploadImage request.
 type UploadImageOptsBuilder interface {
@@ -229,6 +357,12 @@ type UploadImageOptsBuilder interface {
 }
 
 // UploadImageOpts contains options for uploading a Volume to image storage.
+//
+// The Block Storage service does not take the store of the image as a request
+// parameter: with an Image service with multiple stores enabled, the image is
+// uploaded to the store named by the "image_service:store_id" extra spec of
+// the volume's type, or to the default store. Once the image is active, its
+// images.Image.Stores reports where it landed.
 type UploadImageOpts struct {
 	// Container format, may be bare, ofv, ova, etc.
 	ContainerFormat string `json:"container_format,omitempty"`
@@ -250,6 +384,11 @@ func (opts UploadImageOpts) ToVolumeUploadImageMap() (map[string]interface{}, er
 }
 
 // UploadImage will upload an image based on the values in UploadImageOptsBuilder.
+//
+// Uploading a volume that is attached ("in-use") requires
+// UploadImageOpts.Force. Without it the request is rejected, and an
+// ErrVolumeInUse is returned so that callers can decide whether to retry with
+// Force set.
 func UploadImage(client *gophercloud.ServiceClient, id string, opts UploadImageOptsBuilder) (r UploadImageResult) {
 	b, err := opts.ToVolumeUploadImageMap()
 	if err != nil {
@@ -259,11 +398,236 @@ func UploadImage(client *gophercloud.ServiceClient, id string, opts UploadImageO
 	_, r.Err = client.Post(actionURL(client, id), b, &r.Body, &gophercloud.RequestOpts{
 		OkCodes: []int{202},
 	})
+	if err, ok := r.Err.(gophercloud.ErrDefault400); ok && internal.IsVolumeInUse(err) {
+		r.Err = ErrVolumeInUse{ErrUnexpectedResponseCode: err.ErrUnexpectedResponseCode, VolumeID: id}
+	}
 	return
 }
 
-// ForceDelete will delete the volume regardless of state.
+// ForceDelete will delete the volume regardless of state, for instance a
+// volume stuck in "error_deleting". It requires administrative privileges.
 func ForceDelete(client *gophercloud.ServiceClient, id string) (r ForceDeleteResult) {
-	_, r.Err = client.Post(actionURL(client, id), map[string]interface{}{"os-force_delete": ""}, nil, nil)
+	_, r.Err = client.Post(actionURL(client, id), map[string]interface{}{"os-force_delete": ""}, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{202},
+	})
+	return
+}
+
+// ResetStatusOptsBuilder allows extensions to add additional parameters to the
+// ResetStatus request.
+type ResetStatusOptsBuilder interface {
+	ToVolumeResetStatusMap() (map[string]interface{}, error)
+}
+
+// ResetStatusOpts contains options for resetting the status fields of a
+// volume in the database, without touching the volume on its backend. Any
+// subset of the fields may be set; only the fields that are set are reset.
+// This object is passed to the volumeactions.ResetStatus function.
+type ResetStatusOpts struct {
+	// Status is the volume status, e.g. "available" or "in-use".
+	Status string `json:"status,omitempty"`
+
+	// AttachStatus is the attach status, e.g. "attached" or "detached".
+	AttachStatus string `json:"attach_status,omitempty"`
+
+	// MigrationStatus is the migration status, e.g. "error" or "success".
+	MigrationStatus string `json:"migration_status,omitempty"`
+}
+
+// ToVolumeResetStatusMap assembles a request body based on the contents of a
+// ResetStatusOpts.
+func (opts ResetStatusOpts) ToVolumeResetStatusMap() (map[string]interface{}, error) {
+	if opts.Status == "" && opts.AttachStatus == "" && opts.MigrationStatus == "" {
+		err := gophercloud.ErrMissingInput{}
+		err.Argument = "volumeactions.ResetStatusOpts"
+		err.Info = "at least one of Status, AttachStatus and MigrationStatus must be set"
+		return nil, err
+	}
+	return gophercloud.BuildRequestBody(opts, "os-reset_status")
+}
+
+// ResetStatus resets the status, attach status or migration status of a
+// volume, for instance to recover a volume stuck in a transitional state. It
+// requires administrative privileges.
+func ResetStatus(client *gophercloud.ServiceClient, id string, opts ResetStatusOptsBuilder) (r ResetStatusResult) {
+	b, err := opts.ToVolumeResetStatusMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = client.Post(actionURL(client, id), b, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{202},
+	})
+	return
+}
+
+// MigrateOptsBuilder allows extensions to add additional parameters to the
+// Migrate request.
+type MigrateOptsBuilder interface {
+	ToVolumeMigrateMap() (map[string]interface{}, error)
+}
+
+// MigrateOpts contains options for migrating a volume to another backend.
+// This object is passed to the volumeactions.Migrate function.
+type MigrateOpts struct {
+	// Host is the backend to migrate the volume to, as "host@backend#pool".
+	Host string `json:"host" required:"true"`
+
+	// ForceHostCopy makes the Block Storage service copy the data itself,
+	// bypassing any driver-assisted migration.
+	ForceHostCopy bool `json:"force_host_copy,omitempty"`
+
+	// LockVolume keeps other operations, such as attaching, from being done
+	// on the volume until the migration has finished. It only applies to
+	// volumes that are available.
+	LockVolume bool `json:"lock_volume,omitempty"`
+}
+
+// ToVolumeMigrateMap assembles a request body based on the contents of a
+// MigrateOpts.
+func (opts MigrateOpts) ToVolumeMigrateMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "os-migrate_volume")
+}
+
+// Migrate moves the volume with the provided ID to another backend. It
+// requires administrative privileges. This operation does not return a
+// response body.
+//
+// Migrating is asynchronous: poll the volume's MigrationStatus, for instance
+// with volumes.WaitForMigration, to know when it has finished.
+func Migrate(client *gophercloud.ServiceClient, id string, opts MigrateOptsBuilder) (r MigrateResult) {
+	b, err := opts.ToVolumeMigrateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = client.Post(actionURL(client, id), b, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{202},
+	})
+	return
+}
+
+// MigrateCompleteOptsBuilder allows extensions to add additional parameters to
+// the MigrateComplete request.
+type MigrateCompleteOptsBuilder interface {
+	ToVolumeMigrateCompleteMap() (map[string]interface{}, error)
+}
+
+// MigrateCompleteOpts contains options for completing the migration of a
+// volume. This object is passed to the volumeactions.MigrateComplete
+// function.
+type MigrateCompleteOpts struct {
+	// NewVolume is the ID of the volume the data was migrated to.
+	NewVolume string `json:"new_volume" required:"true"`
+
+	// Error reports that the migration failed, in which case the new volume
+	// is deleted and the original volume is kept.
+	Error bool `json:"error,omitempty"`
+}
+
+// ToVolumeMigrateCompleteMap assembles a request body based on the contents
+// of a MigrateCompleteOpts.
+func (opts MigrateCompleteOpts) ToVolumeMigrateCompleteMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "os-migrate_volume_completion")
+}
+
+// MigrateComplete completes the migration of the volume with the provided ID
+// to the volume opts.NewVolume, swapping the two. It requires administrative
+// privileges, and is normally called by the Compute service once it has
+// copied the data of an attached volume; a migration requested with Migrate
+// completes on its own. To extract the ID of the volume that is kept from the
+// response, call the Extract method on the MigrateCompleteResult.
+func MigrateComplete(client *gophercloud.ServiceClient, id string, opts MigrateCompleteOptsBuilder) (r MigrateCompleteResult) {
+	b, err := opts.ToVolumeMigrateCompleteMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = client.Post(actionURL(client, id), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	return
+}
+
+// SetBootableOptsBuilder allows extensions to add additional parameters to the
+// SetBootable request.
+type SetBootableOptsBuilder interface {
+	ToVolumeSetBootableMap() (map[string]interface{}, error)
+}
+
+// SetBootableOpts contains options for setting the bootable flag of a volume.
+// This object is passed to the volumeactions.SetBootable function.
+type SetBootableOpts struct {
+	// Bootable is whether the volume can be booted from.
+	Bootable bool `json:"bootable"`
+}
+
+// ToVolumeSetBootableMap assembles a request body based on the contents of a
+// SetBootableOpts.
+func (opts SetBootableOpts) ToVolumeSetBootableMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "os-set_bootable")
+}
+
+// SetBootable marks the volume with the provided ID as bootable, or not, so
+// that the Compute service will, or will not, boot a server from it. This
+// operation does not return a response body.
+//
+// The flag is sent as a bool, but the Block Storage service reports it back
+// as the string "true" or "false" in the volume's Bootable field.
+func SetBootable(client *gophercloud.ServiceClient, id string, opts SetBootableOptsBuilder) (r SetBootableResult) {
+	b, err := opts.ToVolumeSetBootableMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = client.Post(actionURL(client, id), b, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	return
+}
+
+// SetReadOnlyOptsBuilder allows extensions to add additional parameters to the
+// SetReadOnly request.
+type SetReadOnlyOptsBuilder interface {
+	ToVolumeSetReadOnlyMap() (map[string]interface{}, error)
+}
+
+// SetReadOnlyOpts contains options for setting the read-only flag of a volume.
+// This object is passed to the volumeactions.SetReadOnly function.
+type SetReadOnlyOpts struct {
+	// ReadOnly is whether the volume is attached read-only.
+	ReadOnly bool `json:"readonly"`
+}
+
+// ToVolumeSetReadOnlyMap assembles a request body based on the contents of a
+// SetReadOnlyOpts.
+func (opts SetReadOnlyOpts) ToVolumeSetReadOnlyMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "os-update_readonly_flag")
+}
+
+// SetReadOnly marks the volume with the provided ID as read-only, or not.
+// Servers the volume is attached to afterwards can only read from it, which
+// makes it suitable for attaching a multiattach volume to many servers. This
+// operation does not return a response body.
+func SetReadOnly(client *gophercloud.ServiceClient, id string, opts SetReadOnlyOptsBuilder) (r SetReadOnlyResult) {
+	b, err := opts.ToVolumeSetReadOnlyMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = client.Post(actionURL(client, id), b, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{202},
+	})
+	return
+}
+
+// ShowImageMetadata retrieves the metadata of the image a bootable volume was
+// created from. The metadata is stored with the volume, so it remains
+// available after the source image has been deleted.
+func ShowImageMetadata(client *gophercloud.ServiceClient, id string) (r ShowImageMetadataResult) {
+	b := map[string]interface{}{"os-show_image_metadata": nil}
+	_, r.Err = client.Post(actionURL(client, id), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
 	return
 }
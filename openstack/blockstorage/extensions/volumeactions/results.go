@@ -2,6 +2,8 @@ package volumeactions
 
 import (
 	"encoding/json"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/gophercloud/gophercloud"
@@ -57,6 +59,17 @@ type ExtendSizeResult struct {
 	gophercloud.ErrResult
 }
 
+// RetypeResult contains the response body and error from a Retype request.
+type RetypeResult struct {
+	gophercloud.ErrResult
+}
+
+// ExtendVolumeCompletionResult contains the response body and error from an
+// ExtendVolumeCompletion request.
+type ExtendVolumeCompletionResult struct {
+	gophercloud.ErrResult
+}
+
 // Extract will get the connection information out of the
 // InitializeConnectionResult object.
 //
@@ -189,3 +202,141 @@ func (r UploadImageResult) Extract() (VolumeImage, error) {
 type ForceDeleteResult struct {
 	gophercloud.ErrResult
 }
+
+// ResetStatusResult contains the response body and error from a ResetStatus
+// request.
+type ResetStatusResult struct {
+	gophercloud.ErrResult
+}
+
+// MigrateResult contains the response body and error from a Migrate request.
+type MigrateResult struct {
+	gophercloud.ErrResult
+}
+
+// MigrateCompleteResult contains the response body and error from a
+// MigrateComplete request.
+type MigrateCompleteResult struct {
+	gophercloud.Result
+}
+
+// Extract returns the ID of the volume that is kept after a MigrateComplete
+// request, which is the ID of the original volume.
+func (r MigrateCompleteResult) Extract() (string, error) {
+	var s struct {
+		SaveVolumeID string `json:"save_volume_id"`
+	}
+	err := r.ExtractInto(&s)
+	return s.SaveVolumeID, err
+}
+
+// SetBootableResult contains the response body and error from a SetBootable
+// request.
+type SetBootableResult struct {
+	gophercloud.ErrResult
+}
+
+// SetReadOnlyResult contains the response body and error from a SetReadOnly
+// request.
+type SetReadOnlyResult struct {
+	gophercloud.ErrResult
+}
+
+// ShowImageMetadataResult contains the response body and error from a
+// ShowImageMetadata request.
+type ShowImageMetadataResult struct {
+	gophercloud.Result
+}
+
+// ImageMetadata is the metadata of the image a volume was created from.
+type ImageMetadata struct {
+	// ImageID is the ID of the source image.
+	ImageID string
+
+	// ImageName is the name of the source image.
+	ImageName string
+
+	// Checksum is the checksum of the source image's data.
+	Checksum string
+
+	// ContainerFormat is the container format of the source image.
+	ContainerFormat string
+
+	// DiskFormat is the disk format of the source image.
+	DiskFormat string
+
+	// MinDisk is the minimum disk size in GB the source image requires.
+	MinDisk int
+
+	// MinRAM is the minimum amount of RAM in MB the source image requires.
+	MinRAM int
+
+	// Size is the size of the source image's data in bytes.
+	Size int64
+
+	// Metadata holds all the metadata as strings, including the keys that
+	// are parsed into the fields above and any image properties.
+	Metadata map[string]string
+}
+
+// Extract interprets a ShowImageMetadataResult as an ImageMetadata. Numeric
+// fields are parsed whether the service reports them as numbers or strings.
+func (r ShowImageMetadataResult) Extract() (ImageMetadata, error) {
+	var s struct {
+		Metadata map[string]interface{} `json:"metadata"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return ImageMetadata{}, err
+	}
+
+	im := ImageMetadata{Metadata: make(map[string]string, len(s.Metadata))}
+	for k, v := range s.Metadata {
+		switch t := v.(type) {
+		case string:
+			im.Metadata[k] = t
+		case float64:
+			im.Metadata[k] = strconv.FormatFloat(t, 'f', -1, 64)
+		case nil:
+			im.Metadata[k] = ""
+		default:
+			im.Metadata[k] = fmt.Sprint(t)
+		}
+	}
+
+	im.ImageID = im.Metadata["image_id"]
+	im.ImageName = im.Metadata["image_name"]
+	im.Checksum = im.Metadata["checksum"]
+	im.ContainerFormat = im.Metadata["container_format"]
+	im.DiskFormat = im.Metadata["disk_format"]
+
+	minDisk, err := parseImageMetadataInt(im.Metadata, "min_disk")
+	if err != nil {
+		return ImageMetadata{}, err
+	}
+	minRAM, err := parseImageMetadataInt(im.Metadata, "min_ram")
+	if err != nil {
+		return ImageMetadata{}, err
+	}
+	im.MinDisk, im.MinRAM = int(minDisk), int(minRAM)
+
+	if im.Size, err = parseImageMetadataInt(im.Metadata, "size"); err != nil {
+		return ImageMetadata{}, err
+	}
+
+	return im, nil
+}
+
+// parseImageMetadataInt parses the metadata value with the provided key as an
+// integer. A missing or empty value is parsed as 0.
+func parseImageMetadataInt(metadata map[string]string, key string) (int64, error) {
+	v := metadata[key]
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Unable to parse image metadata %s [%s]: %s", key, v, err)
+	}
+	return n, nil
+}
@@ -126,6 +126,19 @@ func MockUploadImageResponse(t *testing.T) {
 		})
 }
 
+func MockUploadImageInUseResponse(t *testing.T) {
+	th.Mux.HandleFunc("/volumes/cd281d77-8217-4830-be95-9528227c105c/action",
+		func(w http.ResponseWriter, r *http.Request) {
+			th.TestMethod(t, r, "POST")
+			th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+			th.TestJSONRequest(t, r, `{"os-volume_upload_image": {"image_name": "test"}}`)
+
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"badRequest": {"code": 400, "message": "Invalid volume: Volume cd281d77-8217-4830-be95-9528227c105c status must be available, but current status is: in-use."}}`)
+		})
+}
+
 func MockReserveResponse(t *testing.T) {
 	th.Mux.HandleFunc("/volumes/cd281d77-8217-4830-be95-9528227c105c/action",
 		func(w http.ResponseWriter, r *http.Request) {
@@ -277,6 +290,35 @@ func MockExtendSizeResponse(t *testing.T) {
 		})
 }
 
+func MockRetypeResponse(t *testing.T) {
+	th.Mux.HandleFunc("/volumes/cd281d77-8217-4830-be95-9528227c105c/action",
+		func(w http.ResponseWriter, r *http.Request) {
+			th.TestMethod(t, r, "POST")
+			th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+			th.TestJSONRequest(t, r, `
+{
+    "os-retype":
+    {
+        "new_type": "ssd",
+        "migration_policy": "on-demand"
+    }
+}
+          `)
+
+			w.WriteHeader(http.StatusAccepted)
+		})
+}
+
+func MockForceDeleteForbiddenResponse(t *testing.T) {
+	th.Mux.HandleFunc("/volumes/d32019d3-bc6e-4319-9c1d-6722fc136a22/action", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprintf(w, `{"forbidden": {"code": 403, "message": "Policy doesn't allow volume_extension:volume_admin_actions:force_delete to be performed."}}`)
+	})
+}
+
 func MockForceDeleteResponse(t *testing.T) {
 	th.Mux.HandleFunc("/volumes/d32019d3-bc6e-4319-9c1d-6722fc136a22/action", func(w http.ResponseWriter, r *http.Request) {
 		th.TestMethod(t, r, "POST")
@@ -285,3 +327,132 @@ func MockForceDeleteResponse(t *testing.T) {
 		w.WriteHeader(http.StatusAccepted)
 	})
 }
+
+func MockResetStatusResponse(t *testing.T, body string) {
+	th.Mux.HandleFunc("/volumes/cd281d77-8217-4830-be95-9528227c105c/action",
+		func(w http.ResponseWriter, r *http.Request) {
+			th.TestMethod(t, r, "POST")
+			th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+			th.TestHeader(t, r, "Content-Type", "application/json")
+			th.TestJSONRequest(t, r, body)
+
+			w.WriteHeader(http.StatusAccepted)
+		})
+}
+
+func MockMigrateResponse(t *testing.T) {
+	th.Mux.HandleFunc("/volumes/cd281d77-8217-4830-be95-9528227c105c/action",
+		func(w http.ResponseWriter, r *http.Request) {
+			th.TestMethod(t, r, "POST")
+			th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+			th.TestHeader(t, r, "Content-Type", "application/json")
+			th.TestJSONRequest(t, r, `
+{
+    "os-migrate_volume": {
+        "host": "cinder-2@lvm#lvm",
+        "force_host_copy": true,
+        "lock_volume": true
+    }
+}
+          `)
+
+			w.WriteHeader(http.StatusAccepted)
+		})
+}
+
+func MockMigrateCompleteResponse(t *testing.T) {
+	th.Mux.HandleFunc("/volumes/cd281d77-8217-4830-be95-9528227c105c/action",
+		func(w http.ResponseWriter, r *http.Request) {
+			th.TestMethod(t, r, "POST")
+			th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+			th.TestHeader(t, r, "Content-Type", "application/json")
+			th.TestJSONRequest(t, r, `
+{
+    "os-migrate_volume_completion": {
+        "new_volume": "2f2b5a3e-7d3c-4c5e-9b0a-6a1d2c3e4f50"
+    }
+}
+          `)
+
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"save_volume_id": "cd281d77-8217-4830-be95-9528227c105c"}`)
+		})
+}
+
+func MockSetBootableResponse(t *testing.T, body string) {
+	th.Mux.HandleFunc("/volumes/cd281d77-8217-4830-be95-9528227c105c/action",
+		func(w http.ResponseWriter, r *http.Request) {
+			th.TestMethod(t, r, "POST")
+			th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+			th.TestHeader(t, r, "Content-Type", "application/json")
+			th.TestJSONRequest(t, r, body)
+
+			w.WriteHeader(http.StatusOK)
+		})
+}
+
+func MockSetReadOnlyResponse(t *testing.T, body string) {
+	th.Mux.HandleFunc("/volumes/cd281d77-8217-4830-be95-9528227c105c/action",
+		func(w http.ResponseWriter, r *http.Request) {
+			th.TestMethod(t, r, "POST")
+			th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+			th.TestHeader(t, r, "Content-Type", "application/json")
+			th.TestJSONRequest(t, r, body)
+
+			w.WriteHeader(http.StatusAccepted)
+		})
+}
+
+func MockExtendVolumeCompletionResponse(t *testing.T) {
+	th.Mux.HandleFunc("/volumes/cd281d77-8217-4830-be95-9528227c105c/action",
+		func(w http.ResponseWriter, r *http.Request) {
+			th.TestMethod(t, r, "POST")
+			th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+			th.TestHeader(t, r, "Content-Type", "application/json")
+			th.TestHeader(t, r, "Accept", "application/json")
+			th.TestJSONRequest(t, r, `
+{
+    "os-extend_volume_completion":
+    {
+        "error": false
+    }
+}
+          `)
+
+			w.WriteHeader(http.StatusAccepted)
+		})
+}
+
+func MockShowImageMetadataResponse(t *testing.T) {
+	th.Mux.HandleFunc("/volumes/cd281d77-8217-4830-be95-9528227c105c/action",
+		func(w http.ResponseWriter, r *http.Request) {
+			th.TestMethod(t, r, "POST")
+			th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+			th.TestHeader(t, r, "Content-Type", "application/json")
+			th.TestJSONRequest(t, r, `
+{
+    "os-show_image_metadata": null
+}
+          `)
+
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+
+			fmt.Fprintf(w, `
+{
+    "metadata": {
+        "checksum": "64d7c1cd2b6f60c92c14662941cb7913",
+        "container_format": "bare",
+        "disk_format": "qcow2",
+        "image_id": "ecb92d98-de08-45db-8235-bbafe317269c",
+        "image_name": "cirros-0.3.4-x86_64-uec",
+        "min_disk": "1",
+        "min_ram": 64,
+        "size": "13167616",
+        "hw_disk_bus": "scsi"
+    }
+}
+      `)
+		})
+}
@@ -1,6 +1,7 @@
 package testing
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -25,6 +26,22 @@ func TestAttach(t *testing.T) {
 	th.AssertNoErr(t, err)
 }
 
+func TestAttachTarget(t *testing.T) {
+	_, err := volumeactions.AttachOpts{MountPoint: "/mnt"}.ToVolumeAttachMap()
+	if _, ok := err.(gophercloud.ErrMissingInput); !ok {
+		t.Fatalf("Expected an ErrMissingInput, got %v", err)
+	}
+
+	_, err = volumeactions.AttachOpts{
+		MountPoint:   "/mnt",
+		InstanceUUID: "50902f4f-a974-46a0-85e9-7efc5e22dfdd",
+		HostName:     "baremetal-01",
+	}.ToVolumeAttachMap()
+	if _, ok := err.(gophercloud.ErrInvalidInput); !ok {
+		t.Fatalf("Expected an ErrInvalidInput, got %v", err)
+	}
+}
+
 func TestBeginDetaching(t *testing.T) {
 	th.SetupHTTP()
 	defer th.TeardownHTTP()
@@ -85,6 +102,19 @@ func TestUploadImage(t *testing.T) {
 	th.AssertDeepEquals(t, expected, actual)
 }
 
+func TestUploadImageInUse(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockUploadImageInUseResponse(t)
+
+	options := volumeactions.UploadImageOpts{ImageName: "test"}
+	err := volumeactions.UploadImage(client.ServiceClient(), "cd281d77-8217-4830-be95-9528227c105c", options).Err
+	if err, ok := err.(volumeactions.ErrVolumeInUse); !ok || err.VolumeID != "cd281d77-8217-4830-be95-9528227c105c" {
+		t.Fatalf("Expected ErrVolumeInUse, got %v", err)
+	}
+}
+
 func TestReserve(t *testing.T) {
 	th.SetupHTTP()
 	defer th.TeardownHTTP()
@@ -155,6 +185,57 @@ func TestExtendSize(t *testing.T) {
 	th.AssertNoErr(t, err)
 }
 
+func TestExtendSizeInvalid(t *testing.T) {
+	err := volumeactions.ExtendSize(client.ServiceClient(), "cd281d77-8217-4830-be95-9528227c105c", volumeactions.ExtendSizeOpts{NewSize: -1}).ExtractErr()
+	if _, ok := err.(gophercloud.ErrInvalidInput); !ok {
+		t.Fatalf("Expected an ErrInvalidInput, got %v", err)
+	}
+
+	err = volumeactions.ExtendSize(client.ServiceClient(), "cd281d77-8217-4830-be95-9528227c105c", volumeactions.ExtendSizeOpts{}).ExtractErr()
+	if _, ok := err.(gophercloud.ErrMissingInput); !ok {
+		t.Fatalf("Expected an ErrMissingInput, got %v", err)
+	}
+}
+
+func TestRetype(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockRetypeResponse(t)
+
+	options := volumeactions.RetypeOpts{
+		NewType:         "ssd",
+		MigrationPolicy: volumeactions.MigrationPolicyOnDemand,
+	}
+
+	err := volumeactions.Retype(client.ServiceClient(), "cd281d77-8217-4830-be95-9528227c105c", options).ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestRetypeInvalidMigrationPolicy(t *testing.T) {
+	options := volumeactions.RetypeOpts{
+		NewType:         "ssd",
+		MigrationPolicy: "always",
+	}
+
+	err := volumeactions.Retype(client.ServiceClient(), "cd281d77-8217-4830-be95-9528227c105c", options).ExtractErr()
+	if _, ok := err.(gophercloud.ErrInvalidInput); !ok {
+		t.Fatalf("Expected an ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestExtendVolumeCompletion(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockExtendVolumeCompletionResponse(t)
+
+	options := volumeactions.ExtendVolumeCompletionOpts{}
+
+	err := volumeactions.ExtendVolumeCompletion(client.ServiceClient(), "cd281d77-8217-4830-be95-9528227c105c", options).ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
 func TestForceDelete(t *testing.T) {
 	th.SetupHTTP()
 	defer th.TeardownHTTP()
@@ -164,3 +245,138 @@ func TestForceDelete(t *testing.T) {
 	res := volumeactions.ForceDelete(client.ServiceClient(), "d32019d3-bc6e-4319-9c1d-6722fc136a22")
 	th.AssertNoErr(t, res.Err)
 }
+
+func TestForceDeleteForbidden(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockForceDeleteForbiddenResponse(t)
+
+	err := volumeactions.ForceDelete(client.ServiceClient(), "d32019d3-bc6e-4319-9c1d-6722fc136a22").ExtractErr()
+	if _, ok := err.(gophercloud.ErrDefault403); !ok {
+		t.Fatalf("Expected an ErrDefault403, got %v", err)
+	}
+}
+
+func TestResetStatus(t *testing.T) {
+	cases := []struct {
+		opts volumeactions.ResetStatusOpts
+		body string
+	}{
+		{
+			volumeactions.ResetStatusOpts{AttachStatus: "detached"},
+			`{"os-reset_status": {"attach_status": "detached"}}`,
+		},
+		{
+			volumeactions.ResetStatusOpts{MigrationStatus: "error"},
+			`{"os-reset_status": {"migration_status": "error"}}`,
+		},
+		{
+			volumeactions.ResetStatusOpts{Status: "available", AttachStatus: "detached", MigrationStatus: "success"},
+			`{"os-reset_status": {"status": "available", "attach_status": "detached", "migration_status": "success"}}`,
+		},
+	}
+
+	for _, c := range cases {
+		th.SetupHTTP()
+		MockResetStatusResponse(t, c.body)
+
+		err := volumeactions.ResetStatus(client.ServiceClient(), "cd281d77-8217-4830-be95-9528227c105c", c.opts).ExtractErr()
+		th.AssertNoErr(t, err)
+		th.TeardownHTTP()
+	}
+}
+
+func TestResetStatusNoFields(t *testing.T) {
+	err := volumeactions.ResetStatus(client.ServiceClient(), "cd281d77-8217-4830-be95-9528227c105c", volumeactions.ResetStatusOpts{}).ExtractErr()
+	if _, ok := err.(gophercloud.ErrMissingInput); !ok {
+		t.Fatalf("Expected an ErrMissingInput, got %v", err)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockMigrateResponse(t)
+
+	opts := volumeactions.MigrateOpts{
+		Host:          "cinder-2@lvm#lvm",
+		ForceHostCopy: true,
+		LockVolume:    true,
+	}
+	err := volumeactions.Migrate(client.ServiceClient(), "cd281d77-8217-4830-be95-9528227c105c", opts).ExtractErr()
+	th.AssertNoErr(t, err)
+
+	err = volumeactions.Migrate(client.ServiceClient(), "cd281d77-8217-4830-be95-9528227c105c", volumeactions.MigrateOpts{}).ExtractErr()
+	if _, ok := err.(gophercloud.ErrMissingInput); !ok {
+		t.Fatalf("Expected an ErrMissingInput, got %v", err)
+	}
+}
+
+func TestMigrateComplete(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockMigrateCompleteResponse(t)
+
+	opts := volumeactions.MigrateCompleteOpts{NewVolume: "2f2b5a3e-7d3c-4c5e-9b0a-6a1d2c3e4f50"}
+	id, err := volumeactions.MigrateComplete(client.ServiceClient(), "cd281d77-8217-4830-be95-9528227c105c", opts).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "cd281d77-8217-4830-be95-9528227c105c", id)
+}
+
+func TestSetBootable(t *testing.T) {
+	for _, bootable := range []bool{true, false} {
+		th.SetupHTTP()
+		MockSetBootableResponse(t, fmt.Sprintf(`{"os-set_bootable": {"bootable": %t}}`, bootable))
+
+		opts := volumeactions.SetBootableOpts{Bootable: bootable}
+		err := volumeactions.SetBootable(client.ServiceClient(), "cd281d77-8217-4830-be95-9528227c105c", opts).ExtractErr()
+		th.AssertNoErr(t, err)
+		th.TeardownHTTP()
+	}
+}
+
+func TestSetReadOnly(t *testing.T) {
+	for _, readOnly := range []bool{true, false} {
+		th.SetupHTTP()
+		MockSetReadOnlyResponse(t, fmt.Sprintf(`{"os-update_readonly_flag": {"readonly": %t}}`, readOnly))
+
+		opts := volumeactions.SetReadOnlyOpts{ReadOnly: readOnly}
+		err := volumeactions.SetReadOnly(client.ServiceClient(), "cd281d77-8217-4830-be95-9528227c105c", opts).ExtractErr()
+		th.AssertNoErr(t, err)
+		th.TeardownHTTP()
+	}
+}
+
+func TestShowImageMetadata(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockShowImageMetadataResponse(t)
+
+	im, err := volumeactions.ShowImageMetadata(client.ServiceClient(), "cd281d77-8217-4830-be95-9528227c105c").Extract()
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, volumeactions.ImageMetadata{
+		ImageID:         "ecb92d98-de08-45db-8235-bbafe317269c",
+		ImageName:       "cirros-0.3.4-x86_64-uec",
+		Checksum:        "64d7c1cd2b6f60c92c14662941cb7913",
+		ContainerFormat: "bare",
+		DiskFormat:      "qcow2",
+		MinDisk:         1,
+		MinRAM:          64,
+		Size:            13167616,
+		Metadata: map[string]string{
+			"checksum":         "64d7c1cd2b6f60c92c14662941cb7913",
+			"container_format": "bare",
+			"disk_format":      "qcow2",
+			"image_id":         "ecb92d98-de08-45db-8235-bbafe317269c",
+			"image_name":       "cirros-0.3.4-x86_64-uec",
+			"min_disk":         "1",
+			"min_ram":          "64",
+			"size":             "13167616",
+			"hw_disk_bus":      "scsi",
+		},
+	}, im)
+}
@@ -102,6 +102,31 @@ func MockCreateResponse(t *testing.T) {
 	})
 }
 
+func MockUpdateResponse(t *testing.T) {
+	th.Mux.HandleFunc("/snapshots/d32019d3-bc6e-4319-9c1d-6722fc136a22", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PUT")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestJSONRequest(t, r, `{"snapshot": {"name": "snapshot-002", "description": "Nightly"}}`)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `
+{
+  "snapshot": {
+    "id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+    "name": "snapshot-002",
+    "description": "Nightly",
+    "volume_id": "1234",
+    "status": "available",
+    "size": 30,
+    "created_at": "2017-05-30T03:35:03.000000",
+    "updated_at": "2017-05-31T03:35:03.000000"
+  }
+}
+    `)
+	})
+}
+
 func MockUpdateMetadataResponse(t *testing.T) {
 	th.Mux.HandleFunc("/snapshots/123/metadata", func(w http.ResponseWriter, r *http.Request) {
 		th.TestMethod(t, r, "PUT")
@@ -85,6 +85,21 @@ func TestCreate(t *testing.T) {
 	th.AssertEquals(t, n.ID, "d32019d3-bc6e-4319-9c1d-6722fc136a22")
 }
 
+func TestUpdate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockUpdateResponse(t)
+
+	options := snapshots.UpdateOpts{Name: "snapshot-002", Description: "Nightly"}
+	v, err := snapshots.Update(client.ServiceClient(), "d32019d3-bc6e-4319-9c1d-6722fc136a22", options).Extract()
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, "snapshot-002", v.Name)
+	th.AssertEquals(t, "Nightly", v.Description)
+	th.AssertEquals(t, time.Date(2017, 5, 31, 3, 35, 3, 0, time.UTC), v.UpdatedAt)
+}
+
 func TestUpdateMetadata(t *testing.T) {
 	th.SetupHTTP()
 	defer th.TeardownHTTP()
@@ -14,6 +14,10 @@ func getURL(c *gophercloud.ServiceClient, id string) string {
 	return deleteURL(c, id)
 }
 
+func updateURL(c *gophercloud.ServiceClient, id string) string {
+	return deleteURL(c, id)
+}
+
 func listURL(c *gophercloud.ServiceClient) string {
 	return createURL(c)
 }
@@ -0,0 +1,68 @@
+/*
+Package attachments provides information and interaction with the volume
+attachments API of the OpenStack Block Storage service. An attachment records
+the connection of a volume to a server; creating or updating it with a host
+connector returns the connection information the host needs to access the
+volume. It replaces the os-attach family of volume actions, and requires the
+client's Microversion to be set to "3.27" or later.
+
+Example to Attach a Volume
+
+	client.Microversion = "3.44"
+
+	attachment, err := attachments.Create(client, attachments.CreateOpts{
+		VolumeUUID:   "289da7f8-6440-407c-9fb4-7db01ec49164",
+		InstanceUUID: "83ec2e3b-4321-422b-8706-a84185f52a0a",
+		Connector: map[string]interface{}{
+			"initiator": "iqn.1993-08.org.debian:01:cad181614cec",
+			"ip":        "192.168.1.20",
+			"platform":  "x86_64",
+			"host":      "tempest-1",
+			"os_type":   "linux2",
+			"multipath": false,
+		},
+		Mode: attachments.ModeReadWrite,
+	}).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	info := attachment.ConnectionInfo
+	fmt.Println(info.DriverVolumeType(), info.Data()["target_iqn"])
+
+	// Connect the host to the volume, then:
+	err = attachments.Complete(client, attachment.ID).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
+Example to List Attachments of a Volume
+
+	listOpts := attachments.ListOpts{
+		VolumeID: "289da7f8-6440-407c-9fb4-7db01ec49164",
+	}
+
+	allPages, err := attachments.List(client, listOpts).AllPages()
+	if err != nil {
+		panic(err)
+	}
+
+	allAttachments, err := attachments.ExtractAttachments(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, attachment := range allAttachments {
+		fmt.Println(attachment)
+	}
+
+Example to Detach a Volume
+
+	attachmentID := "2f9a4b21-3c08-4bfc-81f3-5c4a24b0e8d6"
+
+	err := attachments.Delete(client, attachmentID).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+*/
+package attachments
@@ -0,0 +1,189 @@
+package attachments
+
+import (
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+// AttachMode describes how a volume is attached.
+type AttachMode string
+
+const (
+	// ModeReadWrite attaches the volume for reading and writing.
+	ModeReadWrite AttachMode = "rw"
+
+	// ModeReadOnly attaches the volume for reading only.
+	ModeReadOnly AttachMode = "ro"
+)
+
+// CreateOptsBuilder allows extensions to add additional parameters to the
+// Create request.
+type CreateOptsBuilder interface {
+	ToAttachmentCreateMap() (map[string]interface{}, error)
+}
+
+// CreateOpts contains options for creating an Attachment. This object is
+// passed to the attachments.Create function.
+type CreateOpts struct {
+	// VolumeUUID is the ID of the volume to attach.
+	VolumeUUID string `json:"volume_uuid" required:"true"`
+
+	// InstanceUUID is the ID of the server the volume is attached to.
+	InstanceUUID string `json:"instance_uuid,omitempty"`
+
+	// Connector describes the host the volume is attached to, e.g. its
+	// "initiator", "ip", "host" and "multipath" settings. Without it, the
+	// volume is only reserved, and the connection is set up by a later
+	// Update.
+	Connector map[string]interface{} `json:"connector,omitempty"`
+
+	// Mode is the attach mode. It requires microversion 3.54 or later; the
+	// volume is attached read-write if it is not set.
+	Mode AttachMode `json:"mode,omitempty"`
+}
+
+// ToAttachmentCreateMap assembles a request body based on the contents of a
+// CreateOpts.
+func (opts CreateOpts) ToAttachmentCreateMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "attachment")
+}
+
+// Create will create a new Attachment based on the values in CreateOpts. To
+// extract the Attachment object, including its connection information, from
+// the response, call the Extract method on the CreateResult.
+func Create(client *gophercloud.ServiceClient, opts CreateOptsBuilder) (r CreateResult) {
+	b, err := opts.ToAttachmentCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = client.Post(createURL(client), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200, 202},
+	})
+	return
+}
+
+// Get retrieves the Attachment with the provided ID. To extract the
+// Attachment object from the response, call the Extract method on the
+// GetResult.
+func Get(client *gophercloud.ServiceClient, id string) (r GetResult) {
+	_, r.Err = client.Get(getURL(client, id), &r.Body, nil)
+	return
+}
+
+// ListOptsBuilder allows extensions to add additional parameters to the List
+// request.
+type ListOptsBuilder interface {
+	ToAttachmentListQuery() (string, error)
+}
+
+// ListOpts holds options for listing Attachments. It is passed to the
+// attachments.List function.
+type ListOpts struct {
+	// AllTenants will retrieve attachments of all tenants/projects.
+	AllTenants bool `q:"all_tenants"`
+
+	// VolumeID will filter by the specified volume ID.
+	VolumeID string `q:"volume_id"`
+
+	// InstanceID will filter by the specified server ID.
+	InstanceID string `q:"instance_id"`
+
+	// Status will filter by the specified status.
+	Status string `q:"status"`
+
+	// TenantID will filter by a specific tenant/project ID.
+	// Setting AllTenants is required for this.
+	TenantID string `q:"project_id"`
+
+	// Comma-separated list of sort keys and optional sort directions in the
+	// form of <key>[:<direction>].
+	Sort string `q:"sort"`
+
+	// Requests a page size of items.
+	Limit int `q:"limit"`
+
+	// Used in conjunction with limit to return a slice of items.
+	Offset int `q:"offset"`
+
+	// The ID of the last-seen item.
+	Marker string `q:"marker"`
+}
+
+// ToAttachmentListQuery formats a ListOpts into a query string.
+func (opts ListOpts) ToAttachmentListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	return q.String(), err
+}
+
+// List returns Attachments optionally limited by the conditions provided in
+// ListOpts.
+func List(client *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pager {
+	url := listURL(client)
+	if opts != nil {
+		query, err := opts.ToAttachmentListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return AttachmentPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// UpdateOptsBuilder allows extensions to add additional parameters to the
+// Update request.
+type UpdateOptsBuilder interface {
+	ToAttachmentUpdateMap() (map[string]interface{}, error)
+}
+
+// UpdateOpts contains options for updating an Attachment. This object is
+// passed to the attachments.Update function.
+type UpdateOpts struct {
+	// Connector describes the host the volume is attached to. See
+	// CreateOpts.Connector.
+	Connector map[string]interface{} `json:"connector" required:"true"`
+}
+
+// ToAttachmentUpdateMap assembles a request body based on the contents of an
+// UpdateOpts.
+func (opts UpdateOpts) ToAttachmentUpdateMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "attachment")
+}
+
+// Update sets the host connector of a reserved Attachment, which sets up the
+// connection to the volume. To extract the Attachment object, including its
+// connection information, from the response, call the Extract method on the
+// UpdateResult.
+func Update(client *gophercloud.ServiceClient, id string, opts UpdateOptsBuilder) (r UpdateResult) {
+	b, err := opts.ToAttachmentUpdateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = client.Put(updateURL(client, id), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	return
+}
+
+// Complete marks the Attachment with the provided ID as attached, once the
+// host has connected to the volume. It requires microversion 3.44 or later.
+func Complete(client *gophercloud.ServiceClient, id string) (r CompleteResult) {
+	b := map[string]interface{}{"os-complete": nil}
+	_, r.Err = client.Post(completeURL(client, id), b, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{204},
+	})
+	return
+}
+
+// Delete will delete the Attachment with the provided ID, which detaches the
+// volume once it is the volume's last attachment.
+func Delete(client *gophercloud.ServiceClient, id string) (r DeleteResult) {
+	_, r.Err = client.Delete(deleteURL(client, id), &gophercloud.RequestOpts{
+		OkCodes: []int{200, 202, 204},
+	})
+	return
+}
@@ -0,0 +1,152 @@
+package attachments
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+// Attachment contains all the information associated with a Cinder volume
+// attachment.
+type Attachment struct {
+	// Unique identifier.
+	ID string `json:"id"`
+
+	// ID of the attached volume.
+	VolumeID string `json:"volume_id"`
+
+	// ID of the server the volume is attached to.
+	Instance string `json:"instance"`
+
+	// Current status of the attachment, e.g. "reserved", "attaching" or
+	// "attached".
+	Status string `json:"status"`
+
+	// Attach mode, "rw" or "ro".
+	AttachMode string `json:"attach_mode"`
+
+	// Date the volume was attached.
+	AttachedAt time.Time `json:"-"`
+
+	// Date the volume was detached.
+	DetachedAt time.Time `json:"-"`
+
+	// ConnectionInfo is what the host needs to connect to the volume. It is
+	// only set once a connector has been provided.
+	ConnectionInfo ConnectionInfo `json:"connection_info"`
+}
+
+// UnmarshalJSON converts our JSON API response into our attachment struct
+func (r *Attachment) UnmarshalJSON(b []byte) error {
+	type tmp Attachment
+	var s struct {
+		tmp
+		AttachedAt gophercloud.JSONRFC3339MilliNoZ `json:"attached_at"`
+		DetachedAt gophercloud.JSONRFC3339MilliNoZ `json:"detached_at"`
+	}
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+	*r = Attachment(s.tmp)
+
+	r.AttachedAt = time.Time(s.AttachedAt)
+	r.DetachedAt = time.Time(s.DetachedAt)
+
+	return err
+}
+
+// ConnectionInfo holds the connection information of an attachment as
+// returned by the volume driver. Its layout depends on the driver; the
+// accessors cover the keys common to all of them.
+type ConnectionInfo map[string]interface{}
+
+// DriverVolumeType returns the transport of the connection, e.g. "iscsi",
+// "fibre_channel" or "rbd".
+func (c ConnectionInfo) DriverVolumeType() string {
+	s, _ := c["driver_volume_type"].(string)
+	return s
+}
+
+// Data returns the driver-specific connection properties, such as
+// "target_iqn", "target_portal" and "target_lun" for iSCSI.
+func (c ConnectionInfo) Data() map[string]interface{} {
+	data, _ := c["data"].(map[string]interface{})
+	return data
+}
+
+// AttachmentPage is a pagination.Pager that is returned from a call to the
+// List function.
+type AttachmentPage struct {
+	pagination.LinkedPageBase
+}
+
+// IsEmpty returns true if an AttachmentPage contains no Attachments.
+func (r AttachmentPage) IsEmpty() (bool, error) {
+	attachments, err := ExtractAttachments(r)
+	return len(attachments) == 0, err
+}
+
+// NextPageURL uses the response's embedded link reference to navigate to the
+// next page of results.
+func (r AttachmentPage) NextPageURL() (string, error) {
+	var s struct {
+		Links []gophercloud.Link `json:"attachments_links"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return gophercloud.ExtractNextURL(s.Links)
+}
+
+// ExtractAttachments extracts and returns Attachments. It is used while
+// iterating over an attachments.List call.
+func ExtractAttachments(r pagination.Page) ([]Attachment, error) {
+	var s struct {
+		Attachments []Attachment `json:"attachments"`
+	}
+	err := (r.(AttachmentPage)).ExtractInto(&s)
+	return s.Attachments, err
+}
+
+type commonResult struct {
+	gophercloud.Result
+}
+
+// Extract will get the Attachment object out of the commonResult object.
+func (r commonResult) Extract() (*Attachment, error) {
+	var s struct {
+		Attachment *Attachment `json:"attachment"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Attachment, err
+}
+
+// CreateResult contains the response body and error from a Create request.
+type CreateResult struct {
+	commonResult
+}
+
+// GetResult contains the response body and error from a Get request.
+type GetResult struct {
+	commonResult
+}
+
+// UpdateResult contains the response body and error from an Update request.
+type UpdateResult struct {
+	commonResult
+}
+
+// CompleteResult contains the response body and error from a Complete
+// request.
+type CompleteResult struct {
+	gophercloud.ErrResult
+}
+
+// DeleteResult contains the response body and error from a Delete request.
+type DeleteResult struct {
+	gophercloud.ErrResult
+}
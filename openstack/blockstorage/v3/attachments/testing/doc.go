@@ -0,0 +1,2 @@
+// attachments_v3
+package testing
@@ -0,0 +1,118 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/testhelper"
+	fake "github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+const attachmentBody = `
+{
+  "attachment": {
+    "id": "2f9a4b21-3c08-4bfc-81f3-5c4a24b0e8d6",
+    "volume_id": "289da7f8-6440-407c-9fb4-7db01ec49164",
+    "instance": "83ec2e3b-4321-422b-8706-a84185f52a0a",
+    "status": "attaching",
+    "attach_mode": "rw",
+    "attached_at": "2018-04-05T09:45:23.000000",
+    "detached_at": null,
+    "connection_info": {
+      "driver_volume_type": "iscsi",
+      "data": {
+        "target_discovered": false,
+        "target_iqn": "iqn.2010-10.org.openstack:volume-289da7f8-6440-407c-9fb4-7db01ec49164",
+        "target_portal": "192.168.1.10:3260",
+        "target_lun": 1,
+        "access_mode": "rw"
+      }
+    }
+  }
+}
+`
+
+func MockCreateResponse(t *testing.T) {
+	th.Mux.HandleFunc("/attachments", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestJSONRequest(t, r, `
+{
+  "attachment": {
+    "volume_uuid": "289da7f8-6440-407c-9fb4-7db01ec49164",
+    "instance_uuid": "83ec2e3b-4321-422b-8706-a84185f52a0a",
+    "connector": {
+      "initiator": "iqn.1993-08.org.debian:01:cad181614cec",
+      "ip": "192.168.1.20",
+      "host": "compute-1",
+      "multipath": false
+    },
+    "mode": "rw"
+  }
+}
+      `)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, attachmentBody)
+	})
+}
+
+func MockListResponse(t *testing.T) {
+	th.Mux.HandleFunc("/attachments/detail", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{"volume_id": "289da7f8-6440-407c-9fb4-7db01ec49164"})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `
+{
+  "attachments": [
+    {
+      "id": "2f9a4b21-3c08-4bfc-81f3-5c4a24b0e8d6",
+      "volume_id": "289da7f8-6440-407c-9fb4-7db01ec49164",
+      "instance": "83ec2e3b-4321-422b-8706-a84185f52a0a",
+      "status": "reserved",
+      "attach_mode": "rw",
+      "attached_at": null,
+      "detached_at": null,
+      "connection_info": {}
+    }
+  ]
+}
+      `)
+	})
+}
+
+func MockAttachmentResponse(t *testing.T) {
+	th.Mux.HandleFunc("/attachments/2f9a4b21-3c08-4bfc-81f3-5c4a24b0e8d6", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		switch r.Method {
+		case "GET":
+		case "PUT":
+			th.TestJSONRequest(t, r, `{"attachment": {"connector": {"initiator": "iqn.1993-08.org.debian:01:cad181614cec"}}}`)
+		case "DELETE":
+			w.WriteHeader(http.StatusOK)
+			return
+		default:
+			t.Fatalf("Unexpected method: %s", r.Method)
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, attachmentBody)
+	})
+}
+
+func MockCompleteResponse(t *testing.T) {
+	th.Mux.HandleFunc("/attachments/2f9a4b21-3c08-4bfc-81f3-5c4a24b0e8d6/action", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestJSONRequest(t, r, `{"os-complete": null}`)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,94 @@
+package testing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/attachments"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+func TestCreate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockCreateResponse(t)
+
+	attachment, err := attachments.Create(client.ServiceClient(), attachments.CreateOpts{
+		VolumeUUID:   "289da7f8-6440-407c-9fb4-7db01ec49164",
+		InstanceUUID: "83ec2e3b-4321-422b-8706-a84185f52a0a",
+		Connector: map[string]interface{}{
+			"initiator": "iqn.1993-08.org.debian:01:cad181614cec",
+			"ip":        "192.168.1.20",
+			"host":      "compute-1",
+			"multipath": false,
+		},
+		Mode: attachments.ModeReadWrite,
+	}).Extract()
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, "2f9a4b21-3c08-4bfc-81f3-5c4a24b0e8d6", attachment.ID)
+	th.AssertEquals(t, "attaching", attachment.Status)
+	th.AssertEquals(t, time.Date(2018, 4, 5, 9, 45, 23, 0, time.UTC), attachment.AttachedAt)
+	th.AssertEquals(t, true, attachment.DetachedAt.IsZero())
+	th.AssertEquals(t, "iscsi", attachment.ConnectionInfo.DriverVolumeType())
+	th.AssertEquals(t, "192.168.1.10:3260", attachment.ConnectionInfo.Data()["target_portal"])
+	th.AssertEquals(t, float64(1), attachment.ConnectionInfo.Data()["target_lun"])
+}
+
+func TestCreateMissingVolume(t *testing.T) {
+	res := attachments.Create(client.ServiceClient(), attachments.CreateOpts{})
+	if res.Err == nil {
+		t.Fatalf("Expected error when VolumeUUID is missing")
+	}
+}
+
+func TestList(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockListResponse(t)
+
+	allPages, err := attachments.List(client.ServiceClient(), attachments.ListOpts{
+		VolumeID: "289da7f8-6440-407c-9fb4-7db01ec49164",
+	}).AllPages()
+	th.AssertNoErr(t, err)
+	actual, err := attachments.ExtractAttachments(allPages)
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, 1, len(actual))
+	th.AssertEquals(t, "reserved", actual[0].Status)
+	th.AssertEquals(t, "", actual[0].ConnectionInfo.DriverVolumeType())
+	th.AssertEquals(t, 0, len(actual[0].ConnectionInfo.Data()))
+}
+
+func TestGetUpdateDelete(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockAttachmentResponse(t)
+
+	attachment, err := attachments.Get(client.ServiceClient(), "2f9a4b21-3c08-4bfc-81f3-5c4a24b0e8d6").Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "289da7f8-6440-407c-9fb4-7db01ec49164", attachment.VolumeID)
+
+	attachment, err = attachments.Update(client.ServiceClient(), "2f9a4b21-3c08-4bfc-81f3-5c4a24b0e8d6", attachments.UpdateOpts{
+		Connector: map[string]interface{}{"initiator": "iqn.1993-08.org.debian:01:cad181614cec"},
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "iscsi", attachment.ConnectionInfo.DriverVolumeType())
+
+	err = attachments.Delete(client.ServiceClient(), "2f9a4b21-3c08-4bfc-81f3-5c4a24b0e8d6").ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestComplete(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockCompleteResponse(t)
+
+	err := attachments.Complete(client.ServiceClient(), "2f9a4b21-3c08-4bfc-81f3-5c4a24b0e8d6").ExtractErr()
+	th.AssertNoErr(t, err)
+}
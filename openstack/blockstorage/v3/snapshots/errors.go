@@ -0,0 +1,18 @@
+package snapshots
+
+import (
+	"fmt"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+// ErrVolumeInUse is the error when a snapshot of an attached volume is
+// requested without CreateOpts.Force.
+type ErrVolumeInUse struct {
+	gophercloud.ErrUnexpectedResponseCode
+	VolumeID string
+}
+
+func (e ErrVolumeInUse) Error() string {
+	return fmt.Sprintf("Volume [%s] is in use; set Force to snapshot an attached volume", e.VolumeID)
+}
@@ -2,6 +2,7 @@ package snapshots
 
 import (
 	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/internal"
 	"github.com/gophercloud/gophercloud/pagination"
 )
 
@@ -31,6 +32,10 @@ func (opts CreateOpts) ToSnapshotCreateMap() (map[string]interface{}, error) {
 // Create will create a new Snapshot based on the values in CreateOpts. To
 // extract the Snapshot object from the response, call the Extract method on the
 // CreateResult.
+//
+// Snapshotting a volume that is attached ("in-use") requires CreateOpts.Force.
+// Without it the request is rejected, and an ErrVolumeInUse is returned so
+// that callers can decide whether to retry with Force set.
 func Create(client *gophercloud.ServiceClient, opts CreateOptsBuilder) (r CreateResult) {
 	b, err := opts.ToSnapshotCreateMap()
 	if err != nil {
@@ -40,6 +45,13 @@ func Create(client *gophercloud.ServiceClient, opts CreateOptsBuilder) (r Create
 	_, r.Err = client.Post(createURL(client), b, &r.Body, &gophercloud.RequestOpts{
 		OkCodes: []int{202},
 	})
+	if err, ok := r.Err.(gophercloud.ErrDefault400); ok && internal.IsVolumeInUse(err) {
+		var volumeID string
+		if snapshot, ok := b["snapshot"].(map[string]interface{}); ok {
+			volumeID, _ = snapshot["volume_id"].(string)
+		}
+		r.Err = ErrVolumeInUse{ErrUnexpectedResponseCode: err.ErrUnexpectedResponseCode, VolumeID: volumeID}
+	}
 	return
 }
 
@@ -149,6 +161,67 @@ func UpdateMetadata(client *gophercloud.ServiceClient, id string, opts UpdateMet
 	return
 }
 
+// Metadata requests all the metadata for the given snapshot ID.
+func Metadata(client *gophercloud.ServiceClient, id string) (r GetMetadataResult) {
+	_, r.Err = client.Get(metadataURL(client, id), &r.Body, nil)
+	return
+}
+
+// MetadatumOptsBuilder allows extensions to add additional parameters to the
+// CreateMetadatum request.
+type MetadatumOptsBuilder interface {
+	ToSnapshotMetadatumCreateMap() (map[string]interface{}, string, error)
+}
+
+// MetadatumOpts is a map of length one that contains a key-value pair.
+type MetadatumOpts map[string]string
+
+// ToSnapshotMetadatumCreateMap assembles a body for a CreateMetadatum request
+// based on the contents of a MetadatumOpts.
+func (opts MetadatumOpts) ToSnapshotMetadatumCreateMap() (map[string]interface{}, string, error) {
+	if len(opts) != 1 {
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "snapshots.MetadatumOpts"
+		err.Info = "Must have 1 and only 1 key-value pair"
+		return nil, "", err
+	}
+	var key string
+	for k := range opts {
+		key = k
+	}
+	return map[string]interface{}{"meta": opts}, key, nil
+}
+
+// CreateMetadatum will create or update the key-value pair with the given key
+// for the given snapshot ID, leaving its other metadata untouched.
+func CreateMetadatum(client *gophercloud.ServiceClient, id string, opts MetadatumOptsBuilder) (r CreateMetadatumResult) {
+	b, key, err := opts.ToSnapshotMetadatumCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = client.Put(metadatumURL(client, id, key), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	return
+}
+
+// Metadatum requests the key-value pair with the given key for the given
+// snapshot ID.
+func Metadatum(client *gophercloud.ServiceClient, id, key string) (r GetMetadatumResult) {
+	_, r.Err = client.Get(metadatumURL(client, id, key), &r.Body, nil)
+	return
+}
+
+// DeleteMetadatum will delete the key-value pair with the given key for the
+// given snapshot ID.
+func DeleteMetadatum(client *gophercloud.ServiceClient, id, key string) (r DeleteMetadatumResult) {
+	_, r.Err = client.Delete(metadatumURL(client, id, key), &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	return
+}
+
 // IDFromName is a convienience function that returns a snapshot's ID given its name.
 func IDFromName(client *gophercloud.ServiceClient, name string) (string, error) {
 	count := 0
@@ -179,3 +252,15 @@ func IDFromName(client *gophercloud.ServiceClient, name string) (string, error)
 		return "", gophercloud.ErrMultipleResourcesFound{Name: name, Count: count, ResourceType: "snapshot"}
 	}
 }
+
+// Unmanage removes the snapshot with the provided ID from the Block Storage
+// service without deleting it from the storage backend. It is admin-only.
+// The snapshot is released asynchronously: it enters the "unmanaging" status
+// and then disappears from the service.
+func Unmanage(client *gophercloud.ServiceClient, id string) (r UnmanageResult) {
+	b := map[string]interface{}{"os-unmanage": map[string]interface{}{}}
+	_, r.Err = client.Post(actionURL(client, id), b, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{202},
+	})
+	return
+}
@@ -118,6 +118,59 @@ func (r UpdateMetadataResult) ExtractMetadata() (map[string]interface{}, error)
 	return m.(map[string]interface{}), nil
 }
 
+// GetMetadataResult contains the result of a Metadata request. Call its
+// Extract method to interpret it as a map[string]string.
+type GetMetadataResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets a GetMetadataResult as the snapshot's metadata.
+func (r GetMetadataResult) Extract() (map[string]string, error) {
+	var s struct {
+		Metadata map[string]string `json:"metadata"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Metadata, err
+}
+
+// MetadatumResult contains the result of a call for a single key-value pair.
+type MetadatumResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets any MetadatumResult as a Metadatum, if possible.
+func (r MetadatumResult) Extract() (map[string]string, error) {
+	var s struct {
+		Metadatum map[string]string `json:"meta"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Metadatum, err
+}
+
+// GetMetadatumResult contains the result of a Metadatum request. Call its
+// Extract method to interpret it as a map[string]string.
+type GetMetadatumResult struct {
+	MetadatumResult
+}
+
+// CreateMetadatumResult contains the result of a CreateMetadatum request. Call
+// its Extract method to interpret it as a map[string]string.
+type CreateMetadatumResult struct {
+	MetadatumResult
+}
+
+// DeleteMetadatumResult contains the result of a DeleteMetadatum request. Call
+// its ExtractErr method to determine if the call succeeded or failed.
+type DeleteMetadatumResult struct {
+	gophercloud.ErrResult
+}
+
+// UnmanageResult contains the response body and error from an Unmanage
+// request.
+type UnmanageResult struct {
+	gophercloud.ErrResult
+}
+
 type commonResult struct {
 	gophercloud.Result
 }
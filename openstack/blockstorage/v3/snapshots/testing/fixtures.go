@@ -146,3 +146,57 @@ func MockDeleteResponse(t *testing.T) {
 		w.WriteHeader(http.StatusNoContent)
 	})
 }
+
+func MockCreateInUseResponse(t *testing.T) {
+	th.Mux.HandleFunc("/snapshots", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestJSONRequest(t, r, `{"snapshot": {"volume_id": "1234", "name": "snapshot-001"}}`)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"badRequest": {"code": 400, "message": "Invalid volume: Volume 1234 status must be available, but current status is: in-use."}}`)
+	})
+}
+
+func MockCreateBadRequestResponse(t *testing.T) {
+	th.Mux.HandleFunc("/snapshots", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"badRequest": {"code": 400, "message": "Invalid input received: Metadata key 'in-use' is reserved."}}`)
+	})
+}
+
+func MockMetadatumResponse(t *testing.T) {
+	th.Mux.HandleFunc("/snapshots/123/metadata", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"metadata": {"key": "v1", "foo": "bar"}}`)
+	})
+
+	th.Mux.HandleFunc("/snapshots/123/metadata/key", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		switch r.Method {
+		case "GET":
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"meta": {"key": "v1"}}`)
+		case "PUT":
+			th.TestJSONRequest(t, r, `{"meta": {"key": "v2"}}`)
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"meta": {"key": "v2"}}`)
+		case "DELETE":
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/snapshots"
 	"github.com/gophercloud/gophercloud/pagination"
 	th "github.com/gophercloud/gophercloud/testhelper"
@@ -114,3 +115,59 @@ func TestDelete(t *testing.T) {
 	res := snapshots.Delete(client.ServiceClient(), "d32019d3-bc6e-4319-9c1d-6722fc136a22")
 	th.AssertNoErr(t, res.Err)
 }
+
+func TestCreateInUse(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockCreateInUseResponse(t)
+
+	options := snapshots.CreateOpts{VolumeID: "1234", Name: "snapshot-001"}
+	err := snapshots.Create(client.ServiceClient(), options).Err
+	if err, ok := err.(snapshots.ErrVolumeInUse); !ok {
+		t.Fatalf("Expected ErrVolumeInUse, got %v", err)
+	} else {
+		th.AssertEquals(t, "1234", err.VolumeID)
+		th.AssertEquals(t, 400, err.Actual)
+	}
+}
+
+func TestCreateBadRequest(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockCreateBadRequestResponse(t)
+
+	options := snapshots.CreateOpts{VolumeID: "1234", Name: "snapshot-001", Metadata: map[string]string{"in-use": "yes"}}
+	err := snapshots.Create(client.ServiceClient(), options).Err
+	if _, ok := err.(gophercloud.ErrDefault400); !ok {
+		t.Fatalf("Expected ErrDefault400, got %v", err)
+	}
+}
+
+func TestMetadatum(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockMetadatumResponse(t)
+
+	metadata, err := snapshots.Metadata(client.ServiceClient(), "123").Extract()
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, map[string]string{"key": "v1", "foo": "bar"}, metadata)
+
+	metadatum, err := snapshots.Metadatum(client.ServiceClient(), "123", "key").Extract()
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, map[string]string{"key": "v1"}, metadatum)
+
+	metadatum, err = snapshots.CreateMetadatum(client.ServiceClient(), "123", snapshots.MetadatumOpts{"key": "v2"}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, map[string]string{"key": "v2"}, metadatum)
+
+	err = snapshots.DeleteMetadatum(client.ServiceClient(), "123", "key").ExtractErr()
+	th.AssertNoErr(t, err)
+
+	_, err = snapshots.CreateMetadatum(client.ServiceClient(), "123", snapshots.MetadatumOpts{}).Extract()
+	if _, ok := err.(gophercloud.ErrInvalidInput); !ok {
+		t.Errorf("Expected ErrInvalidInput, got %v", err)
+	}
+}
@@ -25,3 +25,11 @@ func metadataURL(c *gophercloud.ServiceClient, id string) string {
 func updateMetadataURL(c *gophercloud.ServiceClient, id string) string {
 	return metadataURL(c, id)
 }
+
+func metadatumURL(c *gophercloud.ServiceClient, id, key string) string {
+	return c.ServiceURL("snapshots", id, "metadata", key)
+}
+
+func actionURL(c *gophercloud.ServiceClient, id string) string {
+	return c.ServiceURL("snapshots", id, "action")
+}
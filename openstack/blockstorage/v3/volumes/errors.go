@@ -0,0 +1,97 @@
+package volumes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+// ErrVolumeStatus is the error when a volume being waited on enters a status
+// it cannot recover from on its own, such as "error" or "error_extending".
+type ErrVolumeStatus struct {
+	gophercloud.BaseError
+	ID     string
+	Status string
+
+	// Fault is the most recent user message the Block Storage service
+	// recorded for the volume, if it was retrieved.
+	Fault string
+}
+
+func (e ErrVolumeStatus) Error() string {
+	if e.Fault != "" {
+		return fmt.Sprintf("Volume [%s] entered status [%s]: %s", e.ID, e.Status, e.Fault)
+	}
+	return fmt.Sprintf("Volume [%s] entered status [%s]", e.ID, e.Status)
+}
+
+// ErrVolumeDeleteProtected is the error when DeleteMany skips a volume that
+// has been marked with SetDeleteProtection.
+type ErrVolumeDeleteProtected struct {
+	gophercloud.BaseError
+	ID string
+}
+
+func (e ErrVolumeDeleteProtected) Error() string {
+	return fmt.Sprintf("Volume [%s] is protected from deletion", e.ID)
+}
+
+// ErrVolumeHasSnapshots is the error when a volume cannot be unmanaged
+// because the Block Storage service still manages snapshots of it. Set
+// UnmanageOpts.Cascade to unmanage them first.
+type ErrVolumeHasSnapshots struct {
+	gophercloud.ErrUnexpectedResponseCode
+	ID          string
+	SnapshotIDs []string
+}
+
+func (e ErrVolumeHasSnapshots) Error() string {
+	return fmt.Sprintf("Volume [%s] cannot be unmanaged while it has managed snapshots: %s",
+		e.ID, strings.Join(e.SnapshotIDs, ", "))
+}
+
+// ErrVolumeTypeNotInGroup is the error when a volume is created in a
+// consistency group with a volume type the group does not support.
+type ErrVolumeTypeNotInGroup struct {
+	gophercloud.BaseError
+	ConsistencyGroupID string
+	VolumeType         string
+
+	// GroupTypeIDs are the IDs of the volume types the group supports.
+	GroupTypeIDs []string
+}
+
+func (e ErrVolumeTypeNotInGroup) Error() string {
+	return fmt.Sprintf("Volume type [%s] is not supported by consistency group [%s]; supported types: %s",
+		e.VolumeType, e.ConsistencyGroupID, strings.Join(e.GroupTypeIDs, ", "))
+}
+
+// ErrVolumeMigration is the error when the migration of a volume being waited
+// on fails.
+type ErrVolumeMigration struct {
+	gophercloud.BaseError
+	ID string
+
+	// Fault is the most recent user message the Block Storage service
+	// recorded for the volume, if it was retrieved.
+	Fault string
+}
+
+func (e ErrVolumeMigration) Error() string {
+	if e.Fault != "" {
+		return fmt.Sprintf("Migration of volume [%s] failed: %s", e.ID, e.Fault)
+	}
+	return fmt.Sprintf("Migration of volume [%s] failed", e.ID)
+}
+
+// ErrCountNotAvailable is the error when VolumePage.TotalCount is called on a
+// page that does not include the total count of volumes.
+type ErrCountNotAvailable struct {
+	gophercloud.BaseError
+}
+
+func (e ErrCountNotAvailable) Error() string {
+	return "The total count of volumes is not available; " +
+		"it requires ListOpts.WithCount and microversion 3.45 or later"
+}
@@ -1,7 +1,17 @@
 package volumes
 
 import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/snapshots"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumetypes"
 	"github.com/gophercloud/gophercloud/pagination"
 )
 
@@ -19,7 +29,9 @@ type CreateOpts struct {
 	Size int `json:"size" required:"true"`
 	// The availability zone
 	AvailabilityZone string `json:"availability_zone,omitempty"`
-	// ConsistencyGroupID is the ID of a consistency group
+	// ConsistencyGroupID is the ID of a consistency group to add the volume
+	// to. VolumeType must then be set too, to a type the group supports; see
+	// Create for how this is checked.
 	ConsistencyGroupID string `json:"consistencygroup_id,omitempty"`
 	// The volume description
 	Description string `json:"description,omitempty"`
@@ -27,7 +39,8 @@ type CreateOpts struct {
 	Metadata map[string]string `json:"metadata,omitempty"`
 	// The volume name
 	Name string `json:"name,omitempty"`
-	// the ID of the existing volume snapshot
+	// the ID of the existing volume snapshot. Only one of SnapshotID,
+	// SourceVolID and ImageID can be set.
 	SnapshotID string `json:"snapshot_id,omitempty"`
 	// SourceReplica is a UUID of an existing volume to replicate with
 	SourceReplica string `json:"source_replica,omitempty"`
@@ -36,37 +49,330 @@ type CreateOpts struct {
 	// The ID of the image from which you want to create the volume.
 	// Required to create a bootable volume.
 	ImageID string `json:"imageRef,omitempty"`
-	// The associated volume type
+	// The associated volume type. The create API has no parameter naming a
+	// backend host; to place the volume on a particular backend, use a volume
+	// type whose "volume_backend_name" extra spec selects it.
 	VolumeType string `json:"volume_type,omitempty"`
+	// Multiattach makes the volume attachable to more than one server at a
+	// time. It requires microversion 3.50 or later, which can be set for the
+	// call with the client's WithMicroversion; older microversions ignore it.
+	Multiattach bool `json:"multiattach,omitempty"`
+	// SchedulerHints are passed to the scheduler to influence where the volume
+	// is placed. They are sent under the top-level "OS-SCH-HNT:scheduler_hints"
+	// key, where the Block Storage service reads them at every microversion.
+	SchedulerHints map[string]interface{} `json:"-"`
+	// IdempotencyKey, if set, makes Create safe to retry. It is stored in the
+	// volume's metadata under IdempotencyMetadataKey, and Create returns the
+	// existing volume instead of creating a new one if a volume with the same
+	// key already exists. See Create for its limits.
+	IdempotencyKey string `json:"-"`
+	// RequestID, if set, is sent as the request's X-OpenStack-Request-ID, so
+	// that a retried Create can be told apart from a new one by a deployment
+	// that deduplicates requests. It must be of the form returned by
+	// gophercloud.NewRequestID. See Create for which clouds honor it.
+	RequestID string `json:"-"`
 }
 
+// IdempotencyMetadataKey is the metadata key under which Create stores
+// CreateOpts.IdempotencyKey.
+const IdempotencyMetadataKey = "gophercloud_idempotency_key"
+
+// schedulerHintsKey is the top-level request key for scheduler hints.
+const schedulerHintsKey = "OS-SCH-HNT:scheduler_hints"
+
 // ToVolumeCreateMap assembles a request body based on the contents of a
-// CreateOpts.
+// CreateOpts. Scheduler hints are placed under the top-level
+// "OS-SCH-HNT:scheduler_hints" key.
 func (opts CreateOpts) ToVolumeCreateMap() (map[string]interface{}, error) {
-	return gophercloud.BuildRequestBody(opts, "volume")
+	if opts.RequestID != "" && !gophercloud.IsRequestID(opts.RequestID) {
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "volumes.CreateOpts.RequestID"
+		err.Value = opts.RequestID
+		err.Info = "RequestID must be of the form req-<uuid>"
+		return nil, err
+	}
+
+	var sources []string
+	for _, source := range []struct{ name, value string }{
+		{"ImageID", opts.ImageID},
+		{"SnapshotID", opts.SnapshotID},
+		{"SourceVolID", opts.SourceVolID},
+	} {
+		if source.value != "" {
+			sources = append(sources, source.name)
+		}
+	}
+	if len(sources) > 1 {
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "volumes.CreateOpts." + sources[1]
+		err.Info = fmt.Sprintf("only one of ImageID, SnapshotID and SourceVolID can be set, got %s", strings.Join(sources, " and "))
+		return nil, err
+	}
+
+	if opts.ConsistencyGroupID != "" && opts.VolumeType == "" {
+		err := gophercloud.ErrMissingInput{}
+		err.Argument = "volumes.CreateOpts.VolumeType"
+		return nil, err
+	}
+
+	b, err := gophercloud.BuildRequestBody(opts, "volume")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(opts.SchedulerHints) > 0 {
+		b[schedulerHintsKey] = opts.SchedulerHints
+	}
+
+	if opts.IdempotencyKey != "" {
+		volume := b["volume"].(map[string]interface{})
+		metadata, _ := volume["metadata"].(map[string]interface{})
+		if metadata == nil {
+			metadata = make(map[string]interface{})
+		}
+		metadata[IdempotencyMetadataKey] = opts.IdempotencyKey
+		volume["metadata"] = metadata
+	}
+
+	return b, nil
 }
 
 // Create will create a new Volume based on the values in CreateOpts. To extract
 // the Volume object from the response, call the Extract method on the
 // CreateResult.
+//
+// If CreateOpts.IdempotencyKey is set, the project's volumes are first listed
+// by that key and, if one is found, it is retrieved and returned instead of
+// creating a new one. The Block Storage service has no native idempotency, so
+// this is only a check-then-create: two Create calls with the same key that
+// run concurrently can both miss each other and create two volumes, and a
+// volume whose creation is still being recorded may not be listed yet. It
+// protects against retrying a request that already succeeded, not against
+// concurrent callers.
+//
+// If CreateOpts.RequestID is set, it is sent as the X-OpenStack-Request-ID
+// header. The Block Storage service itself does not deduplicate requests by
+// it: it records it as the global request ID, which appears in its logs and
+// is passed on to the services it calls. Only a deployment that puts a
+// deduplicating proxy in front of the service honors it; elsewhere it is a
+// no-op, and IdempotencyKey is what makes a retried Create safe. Reuse the
+// same RequestID and IdempotencyKey on every attempt.
+//
+// If CreateOpts.ConsistencyGroupID is set, the consistency group is retrieved
+// first, and Create fails with an ErrVolumeTypeNotInGroup, without creating
+// the volume, if CreateOpts.VolumeType, given by name or ID, is not one of
+// the group's volume types. The check is skipped if the group does not report
+// its volume types.
 func Create(client *gophercloud.ServiceClient, opts CreateOptsBuilder) (r CreateResult) {
 	b, err := opts.ToVolumeCreateMap()
 	if err != nil {
 		r.Err = err
 		return
 	}
-	_, r.Err = client.Post(createURL(client), b, &r.Body, &gophercloud.RequestOpts{
+
+	var idempotencyKey, requestID, groupID, volumeType string
+	switch o := opts.(type) {
+	case CreateOpts:
+		idempotencyKey, requestID = o.IdempotencyKey, o.RequestID
+		groupID, volumeType = o.ConsistencyGroupID, o.VolumeType
+	case *CreateOpts:
+		idempotencyKey, requestID = o.IdempotencyKey, o.RequestID
+		groupID, volumeType = o.ConsistencyGroupID, o.VolumeType
+	}
+	if groupID != "" {
+		if err := checkConsistencyGroupType(client, groupID, volumeType); err != nil {
+			r.Err = err
+			return
+		}
+	}
+	if idempotencyKey != "" {
+		id, err := findByIdempotencyKey(client, idempotencyKey)
+		if err != nil {
+			r.Err = err
+			return
+		}
+		if id != "" {
+			existing := Get(client, id)
+			r.Body, r.Header, r.Err = existing.Body, existing.Header, existing.Err
+			return
+		}
+	}
+	reqOpts := &gophercloud.RequestOpts{
 		OkCodes: []int{202},
-	})
+	}
+	if requestID != "" {
+		reqOpts.MoreHeaders = map[string]string{gophercloud.RequestIDHeader: requestID}
+	}
+	_, r.Err = client.Post(createURL(client), b, &r.Body, reqOpts)
 	return
 }
 
+// findByIdempotencyKey returns the ID of a volume created with the provided
+// idempotency key, or an empty string if there is none.
+func findByIdempotencyKey(client *gophercloud.ServiceClient, key string) (string, error) {
+	volumes, err := FindVolumesByMetadata(client, map[string]string{IdempotencyMetadataKey: key})
+	if err != nil || len(volumes) == 0 {
+		return "", err
+	}
+	return volumes[0].ID, nil
+}
+
+// checkConsistencyGroupType returns an ErrVolumeTypeNotInGroup if volumeType,
+// a volume type name or ID, is not supported by the consistency group with
+// the provided ID.
+func checkConsistencyGroupType(client *gophercloud.ServiceClient, groupID, volumeType string) error {
+	var s struct {
+		ConsistencyGroup struct {
+			VolumeTypes interface{} `json:"volume_types"`
+		} `json:"consistencygroup"`
+	}
+	_, err := client.Get(consistencyGroupURL(client, groupID), &s, nil)
+	if err != nil {
+		return err
+	}
+
+	// The group's volume types are a list of IDs, or a comma-separated
+	// string of them in older releases.
+	var typeIDs []string
+	switch v := s.ConsistencyGroup.VolumeTypes.(type) {
+	case string:
+		for _, id := range strings.Split(v, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				typeIDs = append(typeIDs, id)
+			}
+		}
+	case []interface{}:
+		for _, id := range v {
+			if id, ok := id.(string); ok {
+				typeIDs = append(typeIDs, id)
+			}
+		}
+	}
+	if len(typeIDs) == 0 {
+		return nil
+	}
+	for _, id := range typeIDs {
+		if id == volumeType {
+			return nil
+		}
+	}
+
+	// The volume types API looks a type up by either its name or its ID.
+	vt, err := volumetypes.Get(client, volumeType).Extract()
+	if err != nil {
+		return err
+	}
+	for _, id := range typeIDs {
+		if id == vt.ID {
+			return nil
+		}
+	}
+
+	return ErrVolumeTypeNotInGroup{ConsistencyGroupID: groupID, VolumeType: volumeType, GroupTypeIDs: typeIDs}
+}
+
+// DeleteOptsBuilder allows extensions to add additional parameters to the
+// DeleteWithOpts request.
+type DeleteOptsBuilder interface {
+	ToVolumeDeleteQuery() (string, error)
+}
+
+// DeleteOpts contains options for deleting a Volume. This object is passed to
+// the volumes.DeleteWithOpts function.
+type DeleteOpts struct {
+	// Cascade will delete all snapshots of the volume as well.
+	Cascade bool `q:"cascade"`
+
+	// Force will delete the volume regardless of its status. It is admin-only
+	// and requires microversion 3.23 or later.
+	Force bool `q:"force"`
+}
+
+// ToVolumeDeleteQuery formats a DeleteOpts into a query string.
+func (opts DeleteOpts) ToVolumeDeleteQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	return q.String(), err
+}
+
 // Delete will delete the existing Volume with the provided ID.
 func Delete(client *gophercloud.ServiceClient, id string) (r DeleteResult) {
-	_, r.Err = client.Delete(deleteURL(client, id), nil)
+	return DeleteWithOpts(client, id, nil)
+}
+
+// DeleteWithOpts will delete the existing Volume with the provided ID, with
+// options such as Cascade or Force. A nil opts behaves like Delete.
+func DeleteWithOpts(client *gophercloud.ServiceClient, id string, opts DeleteOptsBuilder) (r DeleteResult) {
+	url := deleteURL(client, id)
+	if opts != nil {
+		query, err := opts.ToVolumeDeleteQuery()
+		if err != nil {
+			r.Err = err
+			return
+		}
+		url += query
+	}
+	_, r.Err = client.Delete(url, nil)
 	return
 }
 
+// DeleteMany deletes the Volumes with the provided IDs, issuing at most
+// concurrency Delete requests at a time. A failure to delete one volume does
+// not stop the others from being deleted: the returned map holds the error
+// for each volume that could not be deleted, and is empty if all succeeded.
+//
+// Each volume is retrieved before it is deleted, and volumes marked with
+// SetDeleteProtection are skipped with an ErrVolumeDeleteProtected.
+//
+// Call UseTokenLock on the ProviderClient before using DeleteMany if the
+// client may need to reauthenticate.
+func DeleteMany(client *gophercloud.ServiceClient, ids []string, opts DeleteOptsBuilder, concurrency int) (map[string]error, error) {
+	if concurrency < 1 {
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "concurrency"
+		err.Value = concurrency
+		err.Info = "concurrency must be at least 1"
+		return nil, err
+	}
+
+	if opts != nil {
+		if _, err := opts.ToVolumeDeleteQuery(); err != nil {
+			return nil, err
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	errs := make(map[string]error)
+	queue := make(chan string)
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range queue {
+				if err := deleteUnprotected(client, id, opts); err != nil {
+					mu.Lock()
+					errs[id] = err
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		queue <- id
+	}
+	close(queue)
+	wg.Wait()
+
+	return errs, nil
+}
+
 // Get retrieves the Volume with the provided ID. To extract the Volume object
 // from the response, call the Extract method on the GetResult.
 func Get(client *gophercloud.ServiceClient, id string) (r GetResult) {
@@ -92,9 +398,17 @@ type ListOpts struct {
 	// Name will filter by the specified volume name.
 	Name string `q:"name"`
 
-	// Status will filter by the specified status.
+	// Status will filter by the specified status, e.g. "error".
 	Status string `q:"status"`
 
+	// AvailabilityZone will filter by the specified availability zone.
+	AvailabilityZone string `q:"availability_zone"`
+
+	// UpdatedSince will filter to volumes updated at or after the specified
+	// time. It requires microversion 3.60 or later; older releases ignore it,
+	// in which case Volume.UpdatedAt can be compared client-side instead.
+	UpdatedSince time.Time
+
 	// TenantID will filter by a specific tenant/project ID.
 	// Setting AllTenants is required for this.
 	TenantID string `q:"project_id"`
@@ -103,6 +417,13 @@ type ListOpts struct {
 	// form of <key>[:<direction>].
 	Sort string `q:"sort"`
 
+	// SortKey sorts the results on a single key, in the direction of SortDir.
+	// It cannot be combined with Sort.
+	SortKey string `q:"sort_key"`
+
+	// SortDir is the direction of SortKey, "asc" or "desc".
+	SortDir string `q:"sort_dir"`
+
 	// Requests a page size of items.
 	Limit int `q:"limit"`
 
@@ -111,30 +432,284 @@ type ListOpts struct {
 
 	// The ID of the last-seen item.
 	Marker string `q:"marker"`
+
+	// WithCount asks for the total number of volumes matching the filters to
+	// be returned with each page, which VolumePage.TotalCount reports. It
+	// requires microversion 3.45 or later.
+	WithCount bool `q:"with_count"`
+
+	// MarkerFallback enables synthesizing the next page from the ID of the
+	// last volume when the server omits the "next" link but returned a full
+	// page (as many volumes as Limit). It has no effect unless Limit is set.
+	//
+	// This is opt-in because a server that correctly omits "next" on a final
+	// page which happens to be full will cost one extra, empty request.
+	MarkerFallback bool
+
+	// AdaptiveLimit, if set, tunes the page size from one page to the next,
+	// starting from Limit and growing toward the server's effective maximum.
+	// Its PageSize method reports the negotiated size. It has no effect
+	// unless Limit is set.
+	AdaptiveLimit *pagination.AdaptiveLimit
 }
 
 // ToVolumeListQuery formats a ListOpts into a query string.
 func (opts ListOpts) ToVolumeListQuery() (string, error) {
+	if opts.Sort != "" && (opts.SortKey != "" || opts.SortDir != "") {
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "volumes.ListOpts.Sort"
+		err.Value = opts.Sort
+		err.Info = "Sort cannot be used together with SortKey or SortDir"
+		return "", err
+	}
+
 	q, err := gophercloud.BuildQueryString(opts)
-	return q.String(), err
+	if err != nil {
+		return "", err
+	}
+
+	if !opts.UpdatedSince.IsZero() || len(opts.Metadata) > 0 {
+		params := q.Query()
+		if !opts.UpdatedSince.IsZero() {
+			params.Add("updated_at", "gte:"+opts.UpdatedSince.UTC().Format(time.RFC3339))
+		}
+		if len(opts.Metadata) > 0 {
+			params.Set("metadata", metadataFilter(opts.Metadata))
+		}
+		q = &url.URL{RawQuery: params.Encode()}
+	}
+
+	return q.String(), nil
+}
+
+// metadataFilter formats metadata as the Python dict literal the Block
+// Storage service expects for the "metadata" filter, quoting keys and values
+// so that quotes and backslashes in them survive. Keys are sorted so that the
+// query is stable.
+func metadataFilter(metadata map[string]string) string {
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = pythonQuote(k) + ":" + pythonQuote(metadata[k])
+	}
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
+
+// pythonQuote returns s as a single-quoted Python string literal.
+func pythonQuote(s string) string {
+	var b strings.Builder
+	b.WriteByte('\'')
+	for _, r := range s {
+		switch r {
+		case '\\', '\'':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('\'')
+	return b.String()
 }
 
 // List returns Volumes optionally limited by the conditions provided in ListOpts.
 func List(client *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pager {
 	url := listURL(client)
+	var markerFallback bool
+	var adaptiveLimit *pagination.AdaptiveLimit
 	if opts != nil {
 		query, err := opts.ToVolumeListQuery()
 		if err != nil {
 			return pagination.Pager{Err: err}
 		}
 		url += query
+
+		switch o := opts.(type) {
+		case ListOpts:
+			markerFallback = o.MarkerFallback
+			adaptiveLimit = o.AdaptiveLimit
+		case *ListOpts:
+			markerFallback = o.MarkerFallback
+			adaptiveLimit = o.AdaptiveLimit
+		}
 	}
 
 	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
-		return VolumePage{pagination.LinkedPageBase{PageResult: r}}
+		return VolumePage{
+			LinkedPageBase: pagination.LinkedPageBase{PageResult: r},
+			markerFallback: markerFallback,
+			adaptiveLimit:  adaptiveLimit,
+		}
 	})
 }
 
+// deleteUnprotected deletes the volume with the provided ID unless it has been
+// marked with SetDeleteProtection.
+func deleteUnprotected(client *gophercloud.ServiceClient, id string, opts DeleteOptsBuilder) error {
+	volume, err := Get(client, id).Extract()
+	if err != nil {
+		return err
+	}
+	if volume.DeleteProtected() {
+		return ErrVolumeDeleteProtected{ID: id}
+	}
+	return DeleteWithOpts(client, id, opts).ExtractErr()
+}
+
+// DeleteProtectionKey is the metadata key SetDeleteProtection uses to mark a
+// volume as protected from deletion.
+const DeleteProtectionKey = "delete_protection"
+
+// SetDeleteProtection marks or unmarks the volume with the provided ID as
+// protected from deletion by setting or removing the DeleteProtectionKey
+// metadata key. Other metadata keys are left untouched.
+//
+// The Block Storage service has no notion of protected volumes, so this is
+// advisory only: DeleteMany skips protected volumes, but Delete does not check
+// for the key and anyone able to change the volume's metadata can remove it.
+func SetDeleteProtection(client *gophercloud.ServiceClient, id string, protected bool) (r SetDeleteProtectionResult) {
+	if protected {
+		b := map[string]interface{}{
+			"metadata": map[string]string{DeleteProtectionKey: "true"},
+		}
+		_, r.Err = client.Post(metadataURL(client, id), b, nil, &gophercloud.RequestOpts{
+			OkCodes: []int{200},
+		})
+		return
+	}
+
+	_, r.Err = client.Delete(metadatumURL(client, id, DeleteProtectionKey), &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if _, ok := r.Err.(gophercloud.ErrDefault404); ok {
+		// The volume was not protected to begin with.
+		if err := Get(client, id).Err; err == nil {
+			r.Err = nil
+		}
+	}
+	return
+}
+
+// Metadata requests all the metadata for the given volume ID.
+func Metadata(client *gophercloud.ServiceClient, id string) (r GetMetadataResult) {
+	_, r.Err = client.Get(metadataURL(client, id), &r.Body, nil)
+	return
+}
+
+// MetadataOptsBuilder allows extensions to add additional parameters to the
+// CreateMetadata and UpdateMetadata requests.
+type MetadataOptsBuilder interface {
+	ToVolumeMetadataMap() (map[string]interface{}, error)
+}
+
+// MetadataOpts is a map of metadata key-value pairs.
+type MetadataOpts map[string]string
+
+// ToVolumeMetadataMap assembles a body for a CreateMetadata or UpdateMetadata
+// request based on the contents of a MetadataOpts.
+func (opts MetadataOpts) ToVolumeMetadataMap() (map[string]interface{}, error) {
+	return map[string]interface{}{"metadata": opts}, nil
+}
+
+// CreateMetadata merges the given key-value pairs into the metadata of the
+// given volume ID: existing keys are overwritten and other keys are left
+// untouched. To extract the resulting metadata from the response, call the
+// Extract method on the CreateMetadataResult.
+func CreateMetadata(client *gophercloud.ServiceClient, id string, opts MetadataOptsBuilder) (r CreateMetadataResult) {
+	b, err := opts.ToVolumeMetadataMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = client.Post(metadataURL(client, id), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	return
+}
+
+// UpdateMetadata replaces all the metadata of the given volume ID with the
+// given key-value pairs, removing any other keys. To change some keys
+// without touching those set by others, use CreateMetadata or
+// CreateMetadatum instead. To extract the resulting metadata from the
+// response, call the Extract method on the UpdateMetadataResult.
+func UpdateMetadata(client *gophercloud.ServiceClient, id string, opts MetadataOptsBuilder) (r UpdateMetadataResult) {
+	b, err := opts.ToVolumeMetadataMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = client.Put(metadataURL(client, id), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	return
+}
+
+// MetadatumOptsBuilder allows extensions to add additional parameters to the
+// CreateMetadatum request.
+type MetadatumOptsBuilder interface {
+	ToVolumeMetadatumCreateMap() (map[string]interface{}, string, error)
+}
+
+// MetadatumOpts is a map of length one that contains a key-value pair.
+type MetadatumOpts map[string]string
+
+// ToVolumeMetadatumCreateMap assembles a body for a CreateMetadatum request
+// based on the contents of a MetadatumOpts.
+func (opts MetadatumOpts) ToVolumeMetadatumCreateMap() (map[string]interface{}, string, error) {
+	if len(opts) != 1 {
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "volumes.MetadatumOpts"
+		err.Info = "Must have 1 and only 1 key-value pair"
+		return nil, "", err
+	}
+	var key string
+	for k := range opts {
+		key = k
+	}
+	return map[string]interface{}{"meta": opts}, key, nil
+}
+
+// CreateMetadatum will create or update the key-value pair with the given key
+// for the given volume ID, leaving its other metadata untouched.
+func CreateMetadatum(client *gophercloud.ServiceClient, id string, opts MetadatumOptsBuilder) (r CreateMetadatumResult) {
+	b, key, err := opts.ToVolumeMetadatumCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = client.Put(metadatumURL(client, id, key), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	return
+}
+
+// Metadatum requests the key-value pair with the given key for the given
+// volume ID.
+func Metadatum(client *gophercloud.ServiceClient, id, key string) (r GetMetadatumResult) {
+	_, r.Err = client.Get(metadatumURL(client, id, key), &r.Body, nil)
+	return
+}
+
+// DeleteMetadatum will delete the key-value pair with the given key for the
+// given volume ID.
+func DeleteMetadatum(client *gophercloud.ServiceClient, id, key string) (r DeleteMetadatumResult) {
+	_, r.Err = client.Delete(metadatumURL(client, id, key), &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	return
+}
+
 // UpdateOptsBuilder allows extensions to add additional parameters to the
 // Update request.
 type UpdateOptsBuilder interface {
@@ -200,3 +775,156 @@ func IDFromName(client *gophercloud.ServiceClient, name string) (string, error)
 		return "", gophercloud.ErrMultipleResourcesFound{Name: name, Count: count, ResourceType: "volume"}
 	}
 }
+
+// ManageOptsBuilder allows extensions to add additional parameters to the
+// Manage request.
+type ManageOptsBuilder interface {
+	ToVolumeManageMap() (map[string]interface{}, error)
+}
+
+// ManageOpts contains options for bringing an existing backend volume under
+// the management of the Block Storage service. This object is passed to the
+// volumes.Manage function.
+type ManageOpts struct {
+	// Host is the backend the volume is on, as "host@backend#pool".
+	Host string `json:"host" required:"true"`
+
+	// Ref identifies the volume on the backend. Which keys are accepted
+	// depends on the volume driver; most accept "source-name" or
+	// "source-id".
+	Ref map[string]string `json:"ref" required:"true"`
+
+	// Name is the name of the volume.
+	Name string `json:"name,omitempty"`
+
+	// Description is the description of the volume.
+	Description string `json:"description,omitempty"`
+
+	// VolumeType is the name or ID of the volume type of the volume.
+	VolumeType string `json:"volume_type,omitempty"`
+
+	// AvailabilityZone is the availability zone of the volume.
+	AvailabilityZone string `json:"availability_zone,omitempty"`
+
+	// Bootable marks the volume as bootable.
+	Bootable bool `json:"bootable,omitempty"`
+
+	// Metadata is set as the volume's metadata.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// ToVolumeManageMap assembles a request body based on the contents of a
+// ManageOpts.
+func (opts ManageOpts) ToVolumeManageMap() (map[string]interface{}, error) {
+	if len(opts.Ref) == 0 {
+		err := gophercloud.ErrMissingInput{}
+		err.Argument = "volumes.ManageOpts.Ref"
+		return nil, err
+	}
+	return gophercloud.BuildRequestBody(opts, "volume")
+}
+
+// Manage brings a volume that exists on a storage backend, but not in the
+// Block Storage service, under the service's management, without copying or
+// changing its data. It is admin-only. To extract the Volume object from the
+// response, call the Extract method on the ManageResult; the volume is
+// "creating" until the backend has adopted it. Unmanage does the reverse.
+func Manage(client *gophercloud.ServiceClient, opts ManageOptsBuilder) (r ManageResult) {
+	b, err := opts.ToVolumeManageMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = client.Post(manageURL(client), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{202},
+	})
+	return
+}
+
+// UnmanageOpts contains options for an Unmanage call.
+type UnmanageOpts struct {
+	// Cascade unmanages the volume's snapshots before the volume itself.
+	// Without it, a volume with managed snapshots cannot be unmanaged.
+	Cascade bool
+
+	// Timeout is the number of seconds to wait for cascaded snapshots to be
+	// released before the volume is unmanaged. It defaults to 60.
+	Timeout int
+}
+
+// Unmanage removes the volume with the provided ID from the Block Storage
+// service without deleting it from the storage backend. It is admin-only.
+//
+// If the service refuses because the volume has managed snapshots, the
+// error is an ErrVolumeHasSnapshots listing them. With opts.Cascade, each
+// snapshot is unmanaged first and Unmanage waits for the service to release
+// them; if that fails part way, the snapshots unmanaged so far stay
+// unmanaged.
+func Unmanage(client *gophercloud.ServiceClient, id string, opts UnmanageOpts) (r UnmanageResult) {
+	if opts.Cascade {
+		if err := unmanageSnapshots(client, id, opts.Timeout); err != nil {
+			r.Err = err
+			return
+		}
+	}
+
+	b := map[string]interface{}{"os-unmanage": map[string]interface{}{}}
+	_, r.Err = client.Post(actionURL(client, id), b, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{202},
+	})
+	if e, ok := r.Err.(gophercloud.ErrDefault400); ok && !opts.Cascade && blamesSnapshots(e) {
+		if ids, err := snapshotIDs(client, id); err == nil && len(ids) > 0 {
+			r.Err = ErrVolumeHasSnapshots{ErrUnexpectedResponseCode: e.ErrUnexpectedResponseCode, ID: id, SnapshotIDs: ids}
+		}
+	}
+	return
+}
+
+// blamesSnapshots reports whether the Block Storage service refused to
+// unmanage a volume because of its snapshots.
+func blamesSnapshots(err gophercloud.ErrDefault400) bool {
+	fault, ok := gophercloud.ExtractFault(err)
+	return ok && strings.Contains(strings.ToLower(fault.Message), "snapshot")
+}
+
+// unmanageSnapshots unmanages every snapshot of the volume with the provided
+// ID and waits, for up to secs seconds, until none is left.
+func unmanageSnapshots(client *gophercloud.ServiceClient, id string, secs int) error {
+	ids, err := snapshotIDs(client, id)
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+	for _, snapshotID := range ids {
+		if err := snapshots.Unmanage(client, snapshotID).ExtractErr(); err != nil {
+			return err
+		}
+	}
+
+	if secs <= 0 {
+		secs = 60
+	}
+	return gophercloud.Poll(context.Background(), pollInterval, time.Duration(secs)*time.Second, func() (bool, error) {
+		ids, err := snapshotIDs(client, id)
+		return len(ids) == 0, err
+	})
+}
+
+// snapshotIDs returns the IDs of the snapshots of the volume with the
+// provided ID. Snapshots of all projects are listed, so that an administrator
+// unmanaging another project's volume finds them too.
+func snapshotIDs(client *gophercloud.ServiceClient, id string) ([]string, error) {
+	pages, err := snapshots.List(client, snapshots.ListOpts{AllTenants: true, VolumeID: id}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	all, err := snapshots.ExtractSnapshots(pages)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(all))
+	for i, s := range all {
+		ids[i] = s.ID
+	}
+	return ids, nil
+}
@@ -2,6 +2,8 @@ package volumes
 
 import (
 	"encoding/json"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gophercloud/gophercloud"
@@ -34,9 +36,46 @@ func (r *Attachment) UnmarshalJSON(b []byte) error {
 
 	r.AttachedAt = time.Time(s.AttachedAt)
 
+	// Older releases only report the attachment's ID as "id"; newer ones use
+	// "id" for the volume ID and report the attachment's ID separately.
+	if r.AttachmentID == "" && r.ID != r.VolumeID {
+		r.AttachmentID = r.ID
+	}
+
 	return err
 }
 
+// rootDeviceNames are the device names commonly used for a server's root disk.
+var rootDeviceNames = map[string]bool{
+	"vda":  true,
+	"sda":  true,
+	"hda":  true,
+	"xvda": true,
+}
+
+// DeviceName returns the last element of the attachment's Device path, e.g.
+// "vdb" for "/dev/vdb" or "virtio-1a2b3c" for "/dev/disk/by-id/virtio-1a2b3c".
+// It returns an empty string if Device is not set.
+func (r Attachment) DeviceName() string {
+	device := strings.TrimRight(r.Device, "/")
+	if i := strings.LastIndex(device, "/"); i >= 0 {
+		device = device[i+1:]
+	}
+	return device
+}
+
+// IsRootDevice reports whether the attachment's device is one commonly used
+// for a server's root disk, such as /dev/vda or /dev/sda. Devices named by ID
+// or path cannot be identified and are never reported as root devices.
+func (r Attachment) IsRootDevice() bool {
+	return rootDeviceNames[r.DeviceName()]
+}
+
+// StrictKeys are the Volume fields whose values must be unambiguous for size
+// reporting. Set a ServiceClient's StrictKeys to them to reject responses
+// that repeat any of them.
+var StrictKeys = []string{"id", "status", "size"}
+
 // Volume contains all the information associated with an OpenStack Volume.
 type Volume struct {
 	// Unique identifier for the volume.
@@ -73,10 +112,28 @@ type Volume struct {
 	Encrypted bool `json:"encrypted"`
 	// ReplicationStatus is the status of replication.
 	ReplicationStatus string `json:"replication_status"`
+	// MigrationStatus is the status of the volume's latest migration, e.g.
+	// "migrating", "success" or "error". It is only shown to administrators.
+	MigrationStatus string `json:"migration_status"`
 	// ConsistencyGroupID is the consistency group ID.
 	ConsistencyGroupID string `json:"consistencygroup_id"`
 	// Multiattach denotes if the volume is multi-attach capable.
 	Multiattach bool `json:"multiattach"`
+	// VolumeImageMetadata holds the metadata of the image the volume was
+	// created from, if any.
+	VolumeImageMetadata map[string]string `json:"volume_image_metadata"`
+}
+
+// ImageID returns the ID of the image the volume was created from, or "" if
+// it was not created from an image or the backend does not report it.
+func (r Volume) ImageID() string {
+	return r.VolumeImageMetadata["image_id"]
+}
+
+// DeleteProtected reports whether the volume has been marked as protected
+// from deletion with SetDeleteProtection.
+func (r Volume) DeleteProtected() bool {
+	return r.Metadata[DeleteProtectionKey] == "true"
 }
 
 // UnmarshalJSON another unmarshalling function
@@ -102,6 +159,12 @@ func (r *Volume) UnmarshalJSON(b []byte) error {
 // VolumePage is a pagination.pager that is returned from a call to the List function.
 type VolumePage struct {
 	pagination.LinkedPageBase
+
+	// markerFallback is set from ListOpts.MarkerFallback.
+	markerFallback bool
+
+	// adaptiveLimit is set from ListOpts.AdaptiveLimit.
+	adaptiveLimit *pagination.AdaptiveLimit
 }
 
 // IsEmpty returns true if a ListResult contains no Volumes.
@@ -110,7 +173,24 @@ func (r VolumePage) IsEmpty() (bool, error) {
 	return len(volumes) == 0, err
 }
 
+// NextPageURL uses the response's embedded link reference to navigate to
+// the next page of results.
 func (page VolumePage) NextPageURL() (string, error) {
+	next, err := page.linkedNextPageURL()
+	if err != nil || page.adaptiveLimit == nil {
+		return next, err
+	}
+
+	volumes, err := ExtractVolumes(page)
+	if err != nil {
+		return "", err
+	}
+	return page.adaptiveLimit.NextPageURL(&page.URL, next, len(volumes))
+}
+
+// linkedNextPageURL returns the next page URL from the "volumes_links"
+// link, falling back to markerNextPageURL if enabled.
+func (page VolumePage) linkedNextPageURL() (string, error) {
 	var s struct {
 		Links []gophercloud.Link `json:"volumes_links"`
 	}
@@ -118,7 +198,60 @@ func (page VolumePage) NextPageURL() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	return gophercloud.ExtractNextURL(s.Links)
+
+	url, err := gophercloud.ExtractNextURL(s.Links)
+	if err != nil || url != "" || !page.markerFallback {
+		return url, err
+	}
+	return page.markerNextPageURL()
+}
+
+// markerNextPageURL builds the next page URL from the ID of the last volume on
+// a full page. It returns "" if the page holds fewer volumes than the requested
+// limit, or if no limit was requested. If the page ends with the marker it
+// was requested with, as when the server ignores the marker, it returns
+// pagination.ErrMarkerNotAdvancing rather than request the same page again.
+func (page VolumePage) markerNextPageURL() (string, error) {
+	q := page.URL.Query()
+	limit, err := strconv.Atoi(q.Get("limit"))
+	if err != nil || limit <= 0 {
+		return "", nil
+	}
+
+	volumes, err := ExtractVolumes(page)
+	if err != nil {
+		return "", err
+	}
+	if len(volumes) < limit {
+		return "", nil
+	}
+
+	marker := volumes[len(volumes)-1].ID
+	if marker == q.Get("marker") {
+		return "", pagination.ErrMarkerNotAdvancing
+	}
+	q.Set("marker", marker)
+	u := page.URL
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// TotalCount returns the total number of volumes matching the filters of the
+// List call, regardless of paging. It is only returned by the Block Storage
+// service if ListOpts.WithCount was set and the client's Microversion is 3.45
+// or later; otherwise an ErrCountNotAvailable is returned.
+func (r VolumePage) TotalCount() (int, error) {
+	var s struct {
+		Count *int `json:"count"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return 0, err
+	}
+	if s.Count == nil {
+		return 0, ErrCountNotAvailable{}
+	}
+	return *s.Count, nil
 }
 
 // ExtractVolumes extracts and returns Volumes. It is used while iterating over a volumes.List call.
@@ -168,3 +301,85 @@ type UpdateResult struct {
 type DeleteResult struct {
 	gophercloud.ErrResult
 }
+
+// ManageResult contains the response body and error from a Manage request.
+type ManageResult struct {
+	commonResult
+}
+
+// UnmanageResult contains the response body and error from an Unmanage
+// request.
+type UnmanageResult struct {
+	gophercloud.ErrResult
+}
+
+// MetadataResult contains the result of a call for all the metadata of a
+// volume.
+type MetadataResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets any MetadataResult as the volume's metadata.
+func (r MetadataResult) Extract() (map[string]string, error) {
+	var s struct {
+		Metadata map[string]string `json:"metadata"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Metadata, err
+}
+
+// GetMetadataResult contains the result of a Metadata request. Call its
+// Extract method to interpret it as a map[string]string.
+type GetMetadataResult struct {
+	MetadataResult
+}
+
+// CreateMetadataResult contains the result of a CreateMetadata request. Call
+// its Extract method to interpret it as a map[string]string.
+type CreateMetadataResult struct {
+	MetadataResult
+}
+
+// UpdateMetadataResult contains the result of an UpdateMetadata request. Call
+// its Extract method to interpret it as a map[string]string.
+type UpdateMetadataResult struct {
+	MetadataResult
+}
+
+// MetadatumResult contains the result of a call for a single key-value pair.
+type MetadatumResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets any MetadatumResult as a Metadatum, if possible.
+func (r MetadatumResult) Extract() (map[string]string, error) {
+	var s struct {
+		Metadatum map[string]string `json:"meta"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Metadatum, err
+}
+
+// GetMetadatumResult contains the result of a Metadatum request. Call its
+// Extract method to interpret it as a map[string]string.
+type GetMetadatumResult struct {
+	MetadatumResult
+}
+
+// CreateMetadatumResult contains the result of a CreateMetadatum request. Call
+// its Extract method to interpret it as a map[string]string.
+type CreateMetadatumResult struct {
+	MetadatumResult
+}
+
+// DeleteMetadatumResult contains the result of a DeleteMetadatum request. Call
+// its ExtractErr method to determine if the call succeeded or failed.
+type DeleteMetadatumResult struct {
+	gophercloud.ErrResult
+}
+
+// SetDeleteProtectionResult contains the response body and error from a
+// SetDeleteProtection request.
+type SetDeleteProtectionResult struct {
+	gophercloud.ErrResult
+}
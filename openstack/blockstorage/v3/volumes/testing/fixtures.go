@@ -3,7 +3,11 @@ package testing
 import (
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	th "github.com/gophercloud/gophercloud/testhelper"
 	fake "github.com/gophercloud/gophercloud/testhelper/client"
@@ -140,6 +144,10 @@ func MockGetResponse(t *testing.T) {
     "os-vol-tenant-attr:tenant_id": "304dc00909ac4d0da6c62d816bcb3459",
     "os-vol-mig-status-attr:migstat": null,
     "metadata": {},
+    "volume_image_metadata": {
+      "image_id": "e4c8b6f2-1a2d-4c55-9a5b-8f0c3b1d7e21",
+      "image_name": "golden-image"
+    },
     "status": "available",
     "description": null
   }
@@ -193,6 +201,183 @@ func MockCreateResponse(t *testing.T) {
 	})
 }
 
+func MockCreateWithRequestIDResponse(t *testing.T, requestID string) {
+	th.Mux.HandleFunc("/volumes", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "X-OpenStack-Request-ID", requestID)
+		th.TestJSONRequest(t, r, `{"volume": {"name": "vol-001", "size": 75}}`)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+
+		fmt.Fprintf(w, `
+{
+  "volume": {
+    "size": 75,
+    "id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+    "status": "creating",
+    "name": "vol-001"
+  }
+}
+    `)
+	})
+}
+
+func MockCreateMultiattachResponse(t *testing.T) {
+	th.Mux.HandleFunc("/volumes", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "X-OpenStack-Volume-API-Version", "3.50")
+		th.TestHeader(t, r, "OpenStack-API-Version", "volume 3.50")
+		th.TestJSONRequest(t, r, `{"volume": {"name": "vol-001", "size": 75, "multiattach": true}}`)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+
+		fmt.Fprintf(w, `
+{
+  "volume": {
+    "size": 75,
+    "id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+    "status": "creating",
+    "name": "vol-001",
+    "multiattach": true
+  }
+}
+    `)
+	})
+}
+
+// MockCreateInConsistencyGroupResponse mocks a consistency group supporting
+// the "lvmdriver-1" volume type, and the creation of a volume in it. The
+// number of volumes created is counted in created.
+func MockCreateInConsistencyGroupResponse(t *testing.T, created *int) {
+	th.Mux.HandleFunc("/consistencygroups/6c48e4d4-a5e8-4a0b-a0b5-bc8f2d1c5e0a", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `
+{
+  "consistencygroup": {
+    "id": "6c48e4d4-a5e8-4a0b-a0b5-bc8f2d1c5e0a",
+    "name": "cg-001",
+    "status": "available",
+    "volume_types": ["6685584b-1eac-4da6-b5c3-555430cf68ff"]
+  }
+}
+    `)
+	})
+
+	for name, id := range map[string]string{
+		"lvmdriver-1": "6685584b-1eac-4da6-b5c3-555430cf68ff",
+		"ssd":         "8eb69a46-df97-4e41-9586-9a40a7533803",
+	} {
+		name, id := name, id
+		th.Mux.HandleFunc("/types/"+name, func(w http.ResponseWriter, r *http.Request) {
+			th.TestMethod(t, r, "GET")
+			th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"volume_type": {"id": "%s", "name": "%s"}}`, id, name)
+		})
+	}
+
+	th.Mux.HandleFunc("/volumes", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		*created++
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, `
+{
+  "volume": {
+    "size": 75,
+    "id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+    "status": "creating",
+    "consistencygroup_id": "6c48e4d4-a5e8-4a0b-a0b5-bc8f2d1c5e0a"
+  }
+}
+    `)
+	})
+}
+
+// CreateSchedulerHintsRequest is the body of a Create request with scheduler
+// hints, which are sent at the top level whatever the microversion.
+const CreateSchedulerHintsRequest = `
+{
+    "volume": {
+        "name": "vol-001",
+        "size": 75
+    },
+    "OS-SCH-HNT:scheduler_hints": {
+        "same_host": ["a0cf03a5-d921-4877-bb5c-86d26cf818e1"]
+    }
+}
+`
+
+func MockCreateSchedulerHintsResponse(t *testing.T) {
+	th.Mux.HandleFunc("/volumes", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestJSONRequest(t, r, CreateSchedulerHintsRequest)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, `{"volume": {"id": "d32019d3-bc6e-4319-9c1d-6722fc136a22", "name": "vol-001", "size": 75}}`)
+	})
+}
+
+func MockCreateIdempotentResponse(t *testing.T, existing bool) {
+	th.Mux.HandleFunc("/volumes/detail", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{"metadata": "{'gophercloud_idempotency_key':'req-42'}"})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if existing {
+			fmt.Fprintf(w, `{"volumes": [{"id": "d32019d3-bc6e-4319-9c1d-6722fc136a22", "metadata": {"gophercloud_idempotency_key": "req-42"}}]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"volumes": []}`)
+	})
+
+	th.Mux.HandleFunc("/volumes/d32019d3-bc6e-4319-9c1d-6722fc136a22", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"volume": {"id": "d32019d3-bc6e-4319-9c1d-6722fc136a22", "name": "vol-001", "size": 75, "status": "available"}}`)
+	})
+
+	th.Mux.HandleFunc("/volumes", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestJSONRequest(t, r, `
+{
+    "volume": {
+        "name": "vol-001",
+        "size": 75,
+        "metadata": {
+            "foo": "bar",
+            "gophercloud_idempotency_key": "req-42"
+        }
+    }
+}
+      `)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, `{"volume": {"id": "96c3bda7-c82a-4f50-be73-ca7621794835", "name": "vol-001", "size": 75, "status": "creating"}}`)
+	})
+}
+
 func MockDeleteResponse(t *testing.T) {
 	th.Mux.HandleFunc("/volumes/d32019d3-bc6e-4319-9c1d-6722fc136a22", func(w http.ResponseWriter, r *http.Request) {
 		th.TestMethod(t, r, "DELETE")
@@ -215,3 +400,431 @@ func MockUpdateResponse(t *testing.T) {
         `)
 	})
 }
+
+func MockListWithoutLinksResponse(t *testing.T) {
+	th.Mux.HandleFunc("/volumes/detail", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		r.ParseForm()
+		th.AssertEquals(t, "1", r.Form.Get("limit"))
+
+		switch marker := r.Form.Get("marker"); marker {
+		case "":
+			fmt.Fprintf(w, `{"volumes": [{"id": "289da7f8-6440-407c-9fb4-7db01ec49164", "name": "vol-001"}]}`)
+		case "289da7f8-6440-407c-9fb4-7db01ec49164":
+			fmt.Fprintf(w, `{"volumes": [{"id": "96c3bda7-c82a-4f50-be73-ca7621794835", "name": "vol-002"}]}`)
+		case "96c3bda7-c82a-4f50-be73-ca7621794835":
+			fmt.Fprintf(w, `{"volumes": []}`)
+		default:
+			t.Fatalf("Unexpected marker: [%s]", marker)
+		}
+	})
+}
+
+// MockListCappedResponse serves seven volumes from a server that caps the
+// page size at three, recording the requested limits in limits.
+func MockListCappedResponse(t *testing.T, limits *[]string) {
+	th.Mux.HandleFunc("/volumes/detail", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		r.ParseForm()
+		*limits = append(*limits, r.Form.Get("limit"))
+		limit, _ := strconv.Atoi(r.Form.Get("limit"))
+		if limit > 3 {
+			limit = 3
+		}
+
+		start := 0
+		if marker := r.Form.Get("marker"); marker != "" {
+			start, _ = strconv.Atoi(marker)
+			start++
+		}
+		end := start + limit
+		if end > 7 {
+			end = 7
+		}
+
+		vols := make([]string, 0, end-start)
+		for i := start; i < end; i++ {
+			vols = append(vols, fmt.Sprintf(`{"id": "%d", "name": "vol-%03d"}`, i, i))
+		}
+		links := ""
+		if end < 7 {
+			links = fmt.Sprintf(`, "volumes_links": [{"href": "%s/volumes/detail?marker=%d&limit=%s", "rel": "next"}]`,
+				th.Server.URL, end-1, r.Form.Get("limit"))
+		}
+		fmt.Fprintf(w, `{"volumes": [%s]%s}`, strings.Join(vols, ", "), links)
+	})
+}
+
+func MockManageResponse(t *testing.T) {
+	th.Mux.HandleFunc("/os-volume-manage", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestJSONRequest(t, r, `
+{
+    "volume": {
+        "host": "cinder-1@lvm#lvm",
+        "ref": {"source-name": "lun-0042"},
+        "name": "adopted",
+        "volume_type": "lvmdriver-1",
+        "bootable": true,
+        "metadata": {"team": "storage"}
+    }
+}
+      `)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+
+		fmt.Fprintf(w, `
+{
+  "volume": {
+    "id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+    "status": "creating",
+    "name": "adopted",
+    "size": 0,
+    "volume_type": "lvmdriver-1",
+    "bootable": "true",
+    "metadata": {"team": "storage"}
+  }
+}
+    `)
+	})
+}
+
+// MockUnmanageResponse serves a volume that can only be unmanaged once its
+// snapshots, initially snapshotIDs, have been unmanaged.
+func MockUnmanageResponse(t *testing.T, snapshotIDs ...string) {
+	var mut sync.Mutex
+	remaining := append([]string(nil), snapshotIDs...)
+
+	th.Mux.HandleFunc("/snapshots", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{"all_tenants": "true", "volume_id": "d32019d3-bc6e-4319-9c1d-6722fc136a22"})
+
+		mut.Lock()
+		defer mut.Unlock()
+		snaps := make([]string, len(remaining))
+		for i, id := range remaining {
+			snaps[i] = fmt.Sprintf(`{"id": "%s", "volume_id": "d32019d3-bc6e-4319-9c1d-6722fc136a22"}`, id)
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"snapshots": [%s]}`, strings.Join(snaps, ", "))
+	})
+
+	for _, id := range snapshotIDs {
+		id := id
+		th.Mux.HandleFunc("/snapshots/"+id+"/action", func(w http.ResponseWriter, r *http.Request) {
+			th.TestMethod(t, r, "POST")
+			th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+			th.TestJSONRequest(t, r, `{"os-unmanage": {}}`)
+
+			mut.Lock()
+			defer mut.Unlock()
+			for i, s := range remaining {
+				if s == id {
+					remaining = append(remaining[:i], remaining[i+1:]...)
+					break
+				}
+			}
+			w.WriteHeader(http.StatusAccepted)
+		})
+	}
+
+	th.Mux.HandleFunc("/volumes/d32019d3-bc6e-4319-9c1d-6722fc136a22/action", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestJSONRequest(t, r, `{"os-unmanage": {}}`)
+
+		mut.Lock()
+		defer mut.Unlock()
+		if len(remaining) > 0 {
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"badRequest": {"message": "Invalid volume: Volume cannot have snapshots.", "code": 400}}`)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+// MockUnmanageEncryptedResponse refuses to unmanage a volume, which has a
+// snapshot, for a reason other than the snapshot.
+func MockUnmanageEncryptedResponse(t *testing.T) {
+	th.Mux.HandleFunc("/snapshots", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"snapshots": [{"id": "3b4a2c6f-5e1d-4b8a-9f0e-7c6d5a4b3c2d", "volume_id": "d32019d3-bc6e-4319-9c1d-6722fc136a22"}]}`)
+	})
+
+	th.Mux.HandleFunc("/volumes/d32019d3-bc6e-4319-9c1d-6722fc136a22/action", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"badRequest": {"message": "Invalid volume: Unmanaging encrypted volumes is not supported.", "code": 400}}`)
+	})
+}
+
+// MockListMetadataIgnoredResponse serves a volume list from a server that
+// does not support the metadata filter and returns every volume.
+func MockListMetadataIgnoredResponse(t *testing.T) {
+	th.Mux.HandleFunc("/volumes/detail", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{"metadata": "{'team':'storage'}"})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"volumes": [
+			{"id": "289da7f8-6440-407c-9fb4-7db01ec49164", "name": "vol-001", "metadata": {"team": "network"}},
+			{"id": "96c3bda7-c82a-4f50-be73-ca7621794835", "name": "vol-002", "metadata": {"team": "storage", "tier": "gold"}},
+			{"id": "d32019d3-bc6e-4319-9c1d-6722fc136a22", "name": "vol-003", "metadata": {}}
+		]}`)
+	})
+}
+
+func MockGetStatusResponse(t *testing.T, status string, size int) {
+	th.Mux.HandleFunc("/volumes/d32019d3-bc6e-4319-9c1d-6722fc136a22", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `
+{
+  "volume": {
+    "id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+    "name": "vol-001",
+    "size": %d,
+    "status": "%s"
+  }
+}
+      `, size, status)
+	})
+}
+
+func MockDeleteManyResponse(t *testing.T) {
+	for _, id := range []string{"289da7f8-6440-407c-9fb4-7db01ec49164", "96c3bda7-c82a-4f50-be73-ca7621794835"} {
+		id := id
+		th.Mux.HandleFunc("/volumes/"+id, func(w http.ResponseWriter, r *http.Request) {
+			th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+			if r.Method == "GET" {
+				w.Header().Add("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprintf(w, `{"volume": {"id": "%s", "metadata": {}}}`, id)
+				return
+			}
+			th.TestMethod(t, r, "DELETE")
+			th.TestFormValues(t, r, map[string]string{"cascade": "true"})
+			w.WriteHeader(http.StatusAccepted)
+		})
+	}
+
+	th.Mux.HandleFunc("/volumes/4ad6d3a6-2f7f-4e39-9c2b-1d3a7e1a0f55", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"volume": {"id": "4ad6d3a6-2f7f-4e39-9c2b-1d3a7e1a0f55", "metadata": {"delete_protection": "true"}}}`)
+	})
+
+	th.Mux.HandleFunc("/volumes/d32019d3-bc6e-4319-9c1d-6722fc136a22", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		w.WriteHeader(http.StatusNotFound)
+	})
+}
+
+func MockSetDeleteProtectionResponse(t *testing.T) {
+	th.Mux.HandleFunc("/volumes/d32019d3-bc6e-4319-9c1d-6722fc136a22/metadata", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestJSONRequest(t, r, `{"metadata": {"delete_protection": "true"}}`)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"metadata": {"delete_protection": "true", "foo": "bar"}}`)
+	})
+
+	th.Mux.HandleFunc("/volumes/d32019d3-bc6e-4319-9c1d-6722fc136a22/metadata/delete_protection", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func MockMetadataResponse(t *testing.T) {
+	th.Mux.HandleFunc("/volumes/d32019d3-bc6e-4319-9c1d-6722fc136a22/metadata", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		switch r.Method {
+		case "GET":
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"metadata": {"billing": "team-a", "foo": "bar"}}`)
+		case "POST":
+			th.TestJSONRequest(t, r, `{"metadata": {"billing": "team-b"}}`)
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"metadata": {"billing": "team-b", "foo": "bar"}}`)
+		case "PUT":
+			th.TestJSONRequest(t, r, `{"metadata": {"billing": "team-b"}}`)
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"metadata": {"billing": "team-b"}}`)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+
+	th.Mux.HandleFunc("/volumes/d32019d3-bc6e-4319-9c1d-6722fc136a22/metadata/billing", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		switch r.Method {
+		case "GET":
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"meta": {"billing": "team-a"}}`)
+		case "PUT":
+			th.TestJSONRequest(t, r, `{"meta": {"billing": "team-c"}}`)
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"meta": {"billing": "team-c"}}`)
+		case "DELETE":
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+}
+
+func MockListMessagesResponse(t *testing.T) {
+	th.Mux.HandleFunc("/messages", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{"resource_uuid": "d32019d3-bc6e-4319-9c1d-6722fc136a22"})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `
+{
+  "messages": [
+    {
+      "created_at": "2018-04-09T14:30:00.000000",
+      "resource_uuid": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+      "user_message": "schedule allocate volume: Could not find any available weighted backend."
+    },
+    {
+      "created_at": "2018-04-09T14:35:00.000000",
+      "resource_uuid": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+      "user_message": "copy image to volume: An unknown error occurred."
+    }
+  ]
+}
+      `)
+	})
+}
+
+// migrationState is a volume's migration status and backend host.
+type migrationState struct {
+	status, host string
+}
+
+// MockGetMigrationStatusResponse serves the volume in each of states in turn,
+// repeating the last one.
+func MockGetMigrationStatusResponse(t *testing.T, states ...migrationState) {
+	var calls int
+	th.Mux.HandleFunc("/volumes/d32019d3-bc6e-4319-9c1d-6722fc136a22", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		state := states[len(states)-1]
+		if calls < len(states) {
+			state = states[calls]
+		}
+		calls++
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `
+{
+  "volume": {
+    "id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+    "name": "vol-001",
+    "size": 75,
+    "status": "available",
+    "migration_status": "%s",
+    "os-vol-host-attr:host": "%s"
+  }
+}
+      `, state.status, state.host)
+	})
+}
+
+func MockListCountResponse(t *testing.T) {
+	th.Mux.HandleFunc("/volumes/detail", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		r.ParseForm()
+		if r.Form.Get("with_count") != "true" {
+			fmt.Fprintf(w, `{"volumes": [{"id": "289da7f8-6440-407c-9fb4-7db01ec49164", "name": "vol-001"}]}`)
+			return
+		}
+		th.TestFormValues(t, r, map[string]string{"with_count": "true", "limit": "1"})
+		fmt.Fprintf(w, `{"volumes": [{"id": "289da7f8-6440-407c-9fb4-7db01ec49164", "name": "vol-001"}], "count": 4213}`)
+	})
+}
+
+func MockListStuckResponse(t *testing.T) {
+	th.Mux.HandleFunc("/volumes/detail", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{"all_tenants": "true"})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		recent := time.Now().UTC().Format("2006-01-02T15:04:05.000000")
+		fmt.Fprintf(w, `
+{
+  "volumes": [
+    {"id": "289da7f8-6440-407c-9fb4-7db01ec49164", "status": "attaching", "created_at": "2015-09-17T03:35:03.000000", "updated_at": "2015-09-17T03:36:03.000000"},
+    {"id": "96c3bda7-c82a-4f50-be73-ca7621794835", "status": "creating", "created_at": "2015-09-17T03:32:29.000000", "updated_at": null},
+    {"id": "6e3a3f2c-7e4f-4c69-a5f1-4e0f1e8d9d4b", "status": "deleting", "created_at": "2015-09-17T03:32:29.000000", "updated_at": "%s"},
+    {"id": "f8c8b2e6-9b1d-4b9e-8f41-6d2b6b2c2f0e", "status": "available", "created_at": "2015-09-17T03:32:29.000000", "updated_at": "2015-09-17T03:36:03.000000"}
+  ]
+}
+    `, recent)
+	})
+}
+
+// MockListIgnoringMarkerResponse serves the same full page of volumes
+// whatever the marker.
+func MockListIgnoringMarkerResponse(t *testing.T) {
+	th.Mux.HandleFunc("/volumes/detail", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"volumes": [{"id": "289da7f8-6440-407c-9fb4-7db01ec49164", "name": "vol-001"}]}`)
+	})
+}
@@ -1,9 +1,12 @@
 package testing
 
 import (
+	"encoding/json"
+	"net/url"
 	"testing"
 	"time"
 
+	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/volumetenants"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
 	"github.com/gophercloud/gophercloud/pagination"
@@ -198,6 +201,7 @@ func TestGet(t *testing.T) {
 
 	th.AssertEquals(t, v.Name, "vol-001")
 	th.AssertEquals(t, v.ID, "d32019d3-bc6e-4319-9c1d-6722fc136a22")
+	th.AssertEquals(t, v.ImageID(), "e4c8b6f2-1a2d-4c55-9a5b-8f0c3b1d7e21")
 }
 
 func TestCreate(t *testing.T) {
@@ -214,6 +218,139 @@ func TestCreate(t *testing.T) {
 	th.AssertEquals(t, n.ID, "d32019d3-bc6e-4319-9c1d-6722fc136a22")
 }
 
+func TestCreateWithRequestID(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	requestID := "req-3791a089-9d46-4671-a3f9-55e95e55d2b4"
+	MockCreateWithRequestIDResponse(t, requestID)
+
+	options := volumes.CreateOpts{Size: 75, Name: "vol-001", RequestID: requestID}
+	n, err := volumes.Create(client.ServiceClient(), options).Extract()
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, n.ID, "d32019d3-bc6e-4319-9c1d-6722fc136a22")
+}
+
+func TestCreateMultiattach(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockCreateMultiattachResponse(t)
+
+	sc := client.ServiceClient()
+	sc.Type = "volumev3"
+	options := volumes.CreateOpts{Size: 75, Name: "vol-001", Multiattach: true}
+	n, err := volumes.Create(sc.WithMicroversion("3.50"), options).Extract()
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, n.Multiattach, true)
+	th.AssertEquals(t, sc.Microversion, "")
+}
+
+func TestCreateConflictingSources(t *testing.T) {
+	options := volumes.CreateOpts{Size: 75, ImageID: "e4c8b6f2-1a2d-4c55-9a5b-8f0c3b1d7e21"}
+	_, err := options.ToVolumeCreateMap()
+	th.AssertNoErr(t, err)
+
+	options.SourceVolID = "4ad6d3a6-2f7f-4e39-9c2b-1d3a7e1a0f55"
+	_, err = options.ToVolumeCreateMap()
+	if e, ok := err.(gophercloud.ErrInvalidInput); !ok || e.Argument != "volumes.CreateOpts.SourceVolID" {
+		t.Fatalf("Expected an ErrInvalidInput for SourceVolID, got %v", err)
+	}
+}
+
+func TestCreateInvalidRequestID(t *testing.T) {
+	options := volumes.CreateOpts{Size: 75, Name: "vol-001", RequestID: "retry-1"}
+	_, err := options.ToVolumeCreateMap()
+	if _, ok := err.(gophercloud.ErrInvalidInput); !ok {
+		t.Fatalf("Expected an ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestCreateInConsistencyGroup(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var created int
+	MockCreateInConsistencyGroupResponse(t, &created)
+
+	for _, volumeType := range []string{"6685584b-1eac-4da6-b5c3-555430cf68ff", "lvmdriver-1"} {
+		options := volumes.CreateOpts{
+			Size:               75,
+			ConsistencyGroupID: "6c48e4d4-a5e8-4a0b-a0b5-bc8f2d1c5e0a",
+			VolumeType:         volumeType,
+		}
+		n, err := volumes.Create(client.ServiceClient(), options).Extract()
+		th.AssertNoErr(t, err)
+		th.AssertEquals(t, n.ConsistencyGroupID, "6c48e4d4-a5e8-4a0b-a0b5-bc8f2d1c5e0a")
+	}
+	th.AssertEquals(t, 2, created)
+
+	options := volumes.CreateOpts{
+		Size:               75,
+		ConsistencyGroupID: "6c48e4d4-a5e8-4a0b-a0b5-bc8f2d1c5e0a",
+		VolumeType:         "ssd",
+	}
+	err := volumes.Create(client.ServiceClient(), options).Err
+	if _, ok := err.(volumes.ErrVolumeTypeNotInGroup); !ok {
+		t.Fatalf("Expected an ErrVolumeTypeNotInGroup, got %v", err)
+	}
+	th.AssertEquals(t, 2, created)
+
+	options.VolumeType = ""
+	_, err = options.ToVolumeCreateMap()
+	if _, ok := err.(gophercloud.ErrMissingInput); !ok {
+		t.Fatalf("Expected an ErrMissingInput, got %v", err)
+	}
+}
+
+func TestCreateSchedulerHints(t *testing.T) {
+	options := volumes.CreateOpts{
+		Size: 75,
+		Name: "vol-001",
+		SchedulerHints: map[string]interface{}{
+			"same_host": []string{"a0cf03a5-d921-4877-bb5c-86d26cf818e1"},
+		},
+	}
+
+	for _, microversion := range []string{"", "3.0", "3.50"} {
+		th.SetupHTTP()
+		MockCreateSchedulerHintsResponse(t)
+
+		c := client.ServiceClient()
+		c.Microversion = microversion
+		_, err := volumes.Create(c, options).Extract()
+		th.AssertNoErr(t, err)
+
+		th.TeardownHTTP()
+	}
+}
+
+func TestCreateIdempotent(t *testing.T) {
+	options := volumes.CreateOpts{
+		Size:           75,
+		Name:           "vol-001",
+		Metadata:       map[string]string{"foo": "bar"},
+		IdempotencyKey: "req-42",
+	}
+
+	th.SetupHTTP()
+	MockCreateIdempotentResponse(t, false)
+	v, err := volumes.Create(client.ServiceClient(), options).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "96c3bda7-c82a-4f50-be73-ca7621794835", v.ID)
+	th.TeardownHTTP()
+
+	th.SetupHTTP()
+	MockCreateIdempotentResponse(t, true)
+	v, err = volumes.Create(client.ServiceClient(), options).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "d32019d3-bc6e-4319-9c1d-6722fc136a22", v.ID)
+	th.AssertEquals(t, "available", v.Status)
+	th.TeardownHTTP()
+}
+
 func TestDelete(t *testing.T) {
 	th.SetupHTTP()
 	defer th.TeardownHTTP()
@@ -224,6 +361,93 @@ func TestDelete(t *testing.T) {
 	th.AssertNoErr(t, res.Err)
 }
 
+func TestDeleteWithOpts(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockDeleteManyResponse(t)
+
+	res := volumes.DeleteWithOpts(client.ServiceClient(), "289da7f8-6440-407c-9fb4-7db01ec49164", volumes.DeleteOpts{Cascade: true})
+	th.AssertNoErr(t, res.Err)
+}
+
+func TestDeleteMany(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockDeleteManyResponse(t)
+
+	ids := []string{
+		"289da7f8-6440-407c-9fb4-7db01ec49164",
+		"96c3bda7-c82a-4f50-be73-ca7621794835",
+		"d32019d3-bc6e-4319-9c1d-6722fc136a22",
+		"4ad6d3a6-2f7f-4e39-9c2b-1d3a7e1a0f55",
+	}
+	errs, err := volumes.DeleteMany(client.ServiceClient(), ids, volumes.DeleteOpts{Cascade: true}, 2)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 2, len(errs))
+	if _, ok := errs["d32019d3-bc6e-4319-9c1d-6722fc136a22"].(gophercloud.ErrDefault404); !ok {
+		t.Errorf("Expected ErrDefault404, got %v", errs["d32019d3-bc6e-4319-9c1d-6722fc136a22"])
+	}
+	if _, ok := errs["4ad6d3a6-2f7f-4e39-9c2b-1d3a7e1a0f55"].(volumes.ErrVolumeDeleteProtected); !ok {
+		t.Errorf("Expected ErrVolumeDeleteProtected, got %v", errs["4ad6d3a6-2f7f-4e39-9c2b-1d3a7e1a0f55"])
+	}
+
+	_, err = volumes.DeleteMany(client.ServiceClient(), ids, nil, 0)
+	if _, ok := err.(gophercloud.ErrInvalidInput); !ok {
+		t.Errorf("Expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestSetDeleteProtection(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockSetDeleteProtectionResponse(t)
+
+	err := volumes.SetDeleteProtection(client.ServiceClient(), "d32019d3-bc6e-4319-9c1d-6722fc136a22", true).ExtractErr()
+	th.AssertNoErr(t, err)
+
+	err = volumes.SetDeleteProtection(client.ServiceClient(), "d32019d3-bc6e-4319-9c1d-6722fc136a22", false).ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestMetadata(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockMetadataResponse(t)
+
+	id := "d32019d3-bc6e-4319-9c1d-6722fc136a22"
+	metadata, err := volumes.Metadata(client.ServiceClient(), id).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, map[string]string{"billing": "team-a", "foo": "bar"}, metadata)
+
+	metadata, err = volumes.CreateMetadata(client.ServiceClient(), id, volumes.MetadataOpts{"billing": "team-b"}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, map[string]string{"billing": "team-b", "foo": "bar"}, metadata)
+
+	metadata, err = volumes.UpdateMetadata(client.ServiceClient(), id, volumes.MetadataOpts{"billing": "team-b"}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, map[string]string{"billing": "team-b"}, metadata)
+
+	metadatum, err := volumes.Metadatum(client.ServiceClient(), id, "billing").Extract()
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, map[string]string{"billing": "team-a"}, metadatum)
+
+	metadatum, err = volumes.CreateMetadatum(client.ServiceClient(), id, volumes.MetadatumOpts{"billing": "team-c"}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, map[string]string{"billing": "team-c"}, metadatum)
+
+	err = volumes.DeleteMetadatum(client.ServiceClient(), id, "billing").ExtractErr()
+	th.AssertNoErr(t, err)
+
+	_, err = volumes.CreateMetadatum(client.ServiceClient(), id, volumes.MetadatumOpts{"a": "1", "b": "2"}).Extract()
+	if _, ok := err.(gophercloud.ErrInvalidInput); !ok {
+		t.Fatalf("Expected ErrInvalidInput, got %v", err)
+	}
+}
+
 func TestUpdate(t *testing.T) {
 	th.SetupHTTP()
 	defer th.TeardownHTTP()
@@ -255,3 +479,409 @@ func TestGetWithExtensions(t *testing.T) {
 		t.Errorf("Expected error when providing non-pointer struct")
 	}
 }
+
+func TestListMarkerFallback(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockListWithoutLinksResponse(t)
+
+	allPages, err := volumes.List(client.ServiceClient(), volumes.ListOpts{Limit: 1}).AllPages()
+	th.AssertNoErr(t, err)
+	actual, err := volumes.ExtractVolumes(allPages)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(actual))
+
+	allPages, err = volumes.List(client.ServiceClient(), volumes.ListOpts{Limit: 1, MarkerFallback: true}).AllPages()
+	th.AssertNoErr(t, err)
+	actual, err = volumes.ExtractVolumes(allPages)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 2, len(actual))
+	th.AssertEquals(t, "vol-001", actual[0].Name)
+	th.AssertEquals(t, "vol-002", actual[1].Name)
+}
+
+func TestListMarkerFallbackNotAdvancing(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockListIgnoringMarkerResponse(t)
+
+	_, err := volumes.List(client.ServiceClient(), volumes.ListOpts{Limit: 1, MarkerFallback: true}).AllPages()
+	th.AssertEquals(t, pagination.ErrMarkerNotAdvancing, err)
+}
+
+func TestListAdaptiveLimit(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var limits []string
+	MockListCappedResponse(t, &limits)
+
+	adaptive := &pagination.AdaptiveLimit{}
+	var count int
+	err := volumes.List(client.ServiceClient(), volumes.ListOpts{Limit: 1, AdaptiveLimit: adaptive}).EachPage(func(page pagination.Page) (bool, error) {
+		actual, err := volumes.ExtractVolumes(page)
+		count += len(actual)
+		return true, err
+	})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 7, count)
+	th.AssertDeepEquals(t, []string{"1", "2", "4", "3"}, limits)
+	th.AssertEquals(t, 3, adaptive.PageSize())
+	th.AssertEquals(t, true, adaptive.Capped())
+}
+
+func TestManage(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockManageResponse(t)
+
+	options := volumes.ManageOpts{
+		Host:       "cinder-1@lvm#lvm",
+		Ref:        map[string]string{"source-name": "lun-0042"},
+		Name:       "adopted",
+		VolumeType: "lvmdriver-1",
+		Bootable:   true,
+		Metadata:   map[string]string{"team": "storage"},
+	}
+	v, err := volumes.Manage(client.ServiceClient(), options).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "d32019d3-bc6e-4319-9c1d-6722fc136a22", v.ID)
+	th.AssertEquals(t, "creating", v.Status)
+
+	_, err = volumes.ManageOpts{Host: "cinder-1@lvm#lvm"}.ToVolumeManageMap()
+	if _, ok := err.(gophercloud.ErrMissingInput); !ok {
+		t.Fatalf("Expected an ErrMissingInput, got %v", err)
+	}
+}
+
+func TestUnmanage(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockUnmanageResponse(t)
+
+	err := volumes.Unmanage(client.ServiceClient(), "d32019d3-bc6e-4319-9c1d-6722fc136a22", volumes.UnmanageOpts{}).ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestUnmanageHasSnapshots(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockUnmanageResponse(t, "3b4a2c6f-5e1d-4b8a-9f0e-7c6d5a4b3c2d", "8e9f0a1b-2c3d-4e5f-8a7b-9c0d1e2f3a4b")
+
+	err := volumes.Unmanage(client.ServiceClient(), "d32019d3-bc6e-4319-9c1d-6722fc136a22", volumes.UnmanageOpts{}).ExtractErr()
+	if e, ok := err.(volumes.ErrVolumeHasSnapshots); !ok {
+		t.Fatalf("Expected ErrVolumeHasSnapshots, got %v", err)
+	} else {
+		th.AssertDeepEquals(t, []string{"3b4a2c6f-5e1d-4b8a-9f0e-7c6d5a4b3c2d", "8e9f0a1b-2c3d-4e5f-8a7b-9c0d1e2f3a4b"}, e.SnapshotIDs)
+	}
+
+	err = volumes.Unmanage(client.ServiceClient(), "d32019d3-bc6e-4319-9c1d-6722fc136a22", volumes.UnmanageOpts{Cascade: true}).ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestUnmanageEncrypted(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockUnmanageEncryptedResponse(t)
+
+	err := volumes.Unmanage(client.ServiceClient(), "d32019d3-bc6e-4319-9c1d-6722fc136a22", volumes.UnmanageOpts{}).ExtractErr()
+	if _, ok := err.(gophercloud.ErrDefault400); !ok {
+		t.Fatalf("Expected ErrDefault400, got %v", err)
+	}
+}
+
+func TestWaitForZeroTimeout(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	// The volume is already available, but with no time to wait the
+	// waiters time out without retrieving it.
+	MockGetStatusResponse(t, "available", 100)
+
+	id := "d32019d3-bc6e-4319-9c1d-6722fc136a22"
+	for _, wait := range []func(int) error{
+		func(secs int) error { return volumes.WaitForStatus(client.ServiceClient(), id, "available", secs) },
+		func(secs int) error { return volumes.WaitForExtend(client.ServiceClient(), id, 100, secs) },
+		func(secs int) error { return volumes.WaitForAvailable(client.ServiceClient(), id, secs, nil) },
+		func(secs int) error { return volumes.WaitForMigration(client.ServiceClient(), id, "", secs) },
+	} {
+		for _, secs := range []int{0, -1} {
+			err := wait(secs)
+			if _, ok := err.(gophercloud.ErrTimeout); !ok {
+				t.Fatalf("Expected a gophercloud.ErrTimeout, got %v", err)
+			}
+		}
+	}
+}
+
+func TestWaitForExtend(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockGetStatusResponse(t, "available", 100)
+
+	err := volumes.WaitForExtend(client.ServiceClient(), "d32019d3-bc6e-4319-9c1d-6722fc136a22", 100, 5)
+	th.AssertNoErr(t, err)
+}
+
+func TestWaitForExtendError(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockGetStatusResponse(t, "error_extending", 75)
+
+	err := volumes.WaitForExtend(client.ServiceClient(), "d32019d3-bc6e-4319-9c1d-6722fc136a22", 100, 5)
+	if err, ok := err.(volumes.ErrVolumeStatus); !ok {
+		t.Fatalf("Expected ErrVolumeStatus, got %v", err)
+	} else {
+		th.AssertEquals(t, "error_extending", err.Status)
+	}
+}
+
+func TestAttachmentDevice(t *testing.T) {
+	for device, expected := range map[string]struct {
+		name string
+		root bool
+	}{
+		"/dev/vda":                           {"vda", true},
+		"/dev/vdb":                           {"vdb", false},
+		"/dev/xvda":                          {"xvda", true},
+		"sda":                                {"sda", true},
+		"/dev/disk/by-id/virtio-1a2b3c4d5e6": {"virtio-1a2b3c4d5e6", false},
+		"/dev/vdc/":                          {"vdc", false},
+		"":                                   {"", false},
+	} {
+		a := volumes.Attachment{Device: device}
+		th.AssertEquals(t, expected.name, a.DeviceName())
+		th.AssertEquals(t, expected.root, a.IsRootDevice())
+	}
+}
+
+func TestAttachmentIDFallback(t *testing.T) {
+	var a volumes.Attachment
+	err := json.Unmarshal([]byte(`{"id": "05551600-a936-4d4a-ba42-79a037c1-c91a", "volume_id": "d6cacb1a-8b59-4c88-ad90-d70ebb82bb75"}`), &a)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "05551600-a936-4d4a-ba42-79a037c1-c91a", a.AttachmentID)
+
+	err = json.Unmarshal([]byte(`{"id": "d6cacb1a-8b59-4c88-ad90-d70ebb82bb75", "volume_id": "d6cacb1a-8b59-4c88-ad90-d70ebb82bb75"}`), &a)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "", a.AttachmentID)
+}
+
+func TestWaitForAvailable(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockGetStatusResponse(t, "available", 75)
+
+	var statuses []string
+	err := volumes.WaitForAvailable(client.ServiceClient(), "d32019d3-bc6e-4319-9c1d-6722fc136a22", 5, func(status string) {
+		statuses = append(statuses, status)
+	})
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, []string{"available"}, statuses)
+}
+
+func TestWaitForAvailableError(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockGetStatusResponse(t, "error", 75)
+	MockListMessagesResponse(t)
+
+	err := volumes.WaitForAvailable(client.ServiceClient(), "d32019d3-bc6e-4319-9c1d-6722fc136a22", 5, nil)
+	if err, ok := err.(volumes.ErrVolumeStatus); !ok {
+		t.Fatalf("Expected ErrVolumeStatus, got %v", err)
+	} else {
+		th.AssertEquals(t, "error", err.Status)
+		th.AssertEquals(t, "copy image to volume: An unknown error occurred.", err.Fault)
+	}
+}
+
+func TestListOptsUpdatedSince(t *testing.T) {
+	opts := volumes.ListOpts{
+		Status:       "error",
+		UpdatedSince: time.Date(2018, 4, 9, 16, 30, 0, 0, time.FixedZone("CEST", 2*60*60)),
+	}
+	query, err := opts.ToVolumeListQuery()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "?status=error&updated_at=gte%3A2018-04-09T14%3A30%3A00Z", query)
+}
+
+func TestListOptsMetadata(t *testing.T) {
+	opts := volumes.ListOpts{
+		Metadata: map[string]string{
+			"team":  "o'brien",
+			"path":  `C:\vols`,
+			"owner": "x",
+		},
+	}
+	query, err := opts.ToVolumeListQuery()
+	th.AssertNoErr(t, err)
+
+	u, err := url.Parse(query)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, `{'owner':'x', 'path':'C:\\vols', 'team':'o\'brien'}`, u.Query().Get("metadata"))
+}
+
+func TestListOptsFilters(t *testing.T) {
+	opts := volumes.ListOpts{
+		Name:             "vol-001",
+		AvailabilityZone: "nova",
+		SortKey:          "created_at",
+		SortDir:          "desc",
+	}
+	query, err := opts.ToVolumeListQuery()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "?availability_zone=nova&name=vol-001&sort_dir=desc&sort_key=created_at", query)
+
+	// all_tenants is only sent when it is set.
+	opts.AllTenants = true
+	query, err = opts.ToVolumeListQuery()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "?all_tenants=true&availability_zone=nova&name=vol-001&sort_dir=desc&sort_key=created_at", query)
+
+	opts.Sort = "name:asc"
+	_, err = opts.ToVolumeListQuery()
+	if _, ok := err.(gophercloud.ErrInvalidInput); !ok {
+		t.Fatalf("Expected an ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestFindVolumesByMetadata(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockListMetadataIgnoredResponse(t)
+
+	actual, err := volumes.FindVolumesByMetadata(client.ServiceClient(), map[string]string{"team": "storage"})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(actual))
+	th.AssertEquals(t, "vol-002", actual[0].Name)
+}
+
+func TestWaitForMigration(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	// The volume still shows its previous migration at first.
+	MockGetMigrationStatusResponse(t,
+		migrationState{"success", "cinder-1@lvm#lvm"},
+		migrationState{"migrating", "cinder-1@lvm#lvm"},
+		migrationState{"success", "cinder-2@lvm#lvm"},
+	)
+
+	err := volumes.WaitForMigration(client.ServiceClient(), "d32019d3-bc6e-4319-9c1d-6722fc136a22", "cinder-2@lvm", 5)
+	th.AssertNoErr(t, err)
+}
+
+func TestWaitForMigrationAlreadyFinished(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockGetMigrationStatusResponse(t, migrationState{"success", "cinder-2@lvm#lvm"})
+
+	err := volumes.WaitForMigration(client.ServiceClient(), "d32019d3-bc6e-4319-9c1d-6722fc136a22", "cinder-2@lvm#lvm", 5)
+	th.AssertNoErr(t, err)
+}
+
+func TestWaitForMigrationHostChanged(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	// The scheduler picked the destination and the move was too quick to be
+	// seen in progress.
+	MockGetMigrationStatusResponse(t,
+		migrationState{"success", "cinder-1@lvm#lvm"},
+		migrationState{"success", "cinder-2@lvm#lvm"},
+	)
+
+	err := volumes.WaitForMigration(client.ServiceClient(), "d32019d3-bc6e-4319-9c1d-6722fc136a22", "", 5)
+	th.AssertNoErr(t, err)
+}
+
+func TestWaitForMigrationError(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockGetMigrationStatusResponse(t, migrationState{"error", "cinder-1@lvm#lvm"})
+	MockListMessagesResponse(t)
+
+	err := volumes.WaitForMigration(client.ServiceClient(), "d32019d3-bc6e-4319-9c1d-6722fc136a22", "cinder-2@lvm#lvm", 5)
+	if err, ok := err.(volumes.ErrVolumeMigration); !ok {
+		t.Fatalf("Expected ErrVolumeMigration, got %v", err)
+	} else {
+		th.AssertEquals(t, "copy image to volume: An unknown error occurred.", err.Fault)
+	}
+}
+
+func TestListTotalCount(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockListCountResponse(t)
+
+	err := volumes.List(client.ServiceClient(), volumes.ListOpts{Limit: 1, WithCount: true}).EachPage(func(page pagination.Page) (bool, error) {
+		count, err := page.(volumes.VolumePage).TotalCount()
+		th.AssertNoErr(t, err)
+		th.AssertEquals(t, 4213, count)
+		return false, nil
+	})
+	th.AssertNoErr(t, err)
+
+	err = volumes.List(client.ServiceClient(), volumes.ListOpts{}).EachPage(func(page pagination.Page) (bool, error) {
+		_, err := page.(volumes.VolumePage).TotalCount()
+		if _, ok := err.(volumes.ErrCountNotAvailable); !ok {
+			t.Fatalf("Expected ErrCountNotAvailable, got %v", err)
+		}
+		return false, nil
+	})
+	th.AssertNoErr(t, err)
+}
+
+func TestListAllCapped(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockListResponse(t)
+
+	vols, more, err := volumes.ListAll(client.ServiceClient(), volumes.ListOpts{}, 1)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(vols))
+	th.AssertEquals(t, "289da7f8-6440-407c-9fb4-7db01ec49164", vols[0].ID)
+	th.AssertEquals(t, true, more)
+
+	// The second page is empty, so no more volumes remain.
+	vols, more, err = volumes.ListAll(client.ServiceClient(), volumes.ListOpts{}, 2)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 2, len(vols))
+	th.AssertEquals(t, false, more)
+}
+
+func TestListStuck(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockListStuckResponse(t)
+
+	stuck, err := volumes.ListStuck(client.ServiceClient(), volumes.ListStuckOpts{
+		ListOpts: volumes.ListOpts{AllTenants: true},
+		MinAge:   time.Hour,
+	})
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, 2, len(stuck))
+	th.AssertEquals(t, "289da7f8-6440-407c-9fb4-7db01ec49164", stuck[0].Volume.ID)
+	th.AssertEquals(t, "available", stuck[0].ResetStatus)
+	th.AssertEquals(t, "96c3bda7-c82a-4f50-be73-ca7621794835", stuck[1].Volume.ID)
+	th.AssertEquals(t, "error", stuck[1].ResetStatus)
+
+	th.AssertEquals(t, true, volumes.IsTransitionalStatus("detaching"))
+	th.AssertEquals(t, false, volumes.IsTransitionalStatus("error_deleting"))
+}
@@ -21,3 +21,27 @@ func getURL(c *gophercloud.ServiceClient, id string) string {
 func updateURL(c *gophercloud.ServiceClient, id string) string {
 	return deleteURL(c, id)
 }
+
+func metadataURL(c *gophercloud.ServiceClient, id string) string {
+	return c.ServiceURL("volumes", id, "metadata")
+}
+
+func metadatumURL(c *gophercloud.ServiceClient, id, key string) string {
+	return c.ServiceURL("volumes", id, "metadata", key)
+}
+
+func messagesURL(c *gophercloud.ServiceClient) string {
+	return c.ServiceURL("messages")
+}
+
+func actionURL(c *gophercloud.ServiceClient, id string) string {
+	return c.ServiceURL("volumes", id, "action")
+}
+
+func consistencyGroupURL(c *gophercloud.ServiceClient, id string) string {
+	return c.ServiceURL("consistencygroups", id)
+}
+
+func manageURL(c *gophercloud.ServiceClient) string {
+	return c.ServiceURL("os-volume-manage")
+}
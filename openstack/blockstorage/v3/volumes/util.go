@@ -1,13 +1,34 @@
 package volumes
 
 import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
 	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/pagination"
 )
 
+// pollInterval is how often the WaitFor helpers retrieve the volume.
+const pollInterval = time.Second
+
+// poll calls condition every pollInterval, using gophercloud.Poll, until it
+// is done or secs seconds have elapsed. If secs is zero or less, it returns a
+// gophercloud.ErrTimeout at once without calling condition.
+func poll(secs int, condition func() (bool, error)) error {
+	if secs <= 0 {
+		return gophercloud.ErrTimeout{}
+	}
+	return gophercloud.Poll(context.Background(), pollInterval, time.Duration(secs)*time.Second, condition)
+}
+
 // WaitForStatus will continually poll the resource, checking for a particular
-// status. It will do this for the amount of seconds defined.
+// status. It will do this for up to secs seconds, returning a
+// gophercloud.ErrTimeout if the status is not reached in time; if secs is
+// zero or less, the ErrTimeout is returned at once.
 func WaitForStatus(c *gophercloud.ServiceClient, id, status string, secs int) error {
-	return gophercloud.WaitFor(secs, func() (bool, error) {
+	return poll(secs, func() (bool, error) {
 		current, err := Get(c, id).Extract()
 		if err != nil {
 			return false, err
@@ -20,3 +41,288 @@ func WaitForStatus(c *gophercloud.ServiceClient, id, status string, secs int) er
 		return false, nil
 	})
 }
+
+// WaitForExtend will continually poll a volume after an extend request until
+// it has left the "extending" status with a size of at least newSize. It will
+// do this for up to secs seconds, returning a gophercloud.ErrTimeout if the
+// extend has not finished in time; if secs is zero or less, the ErrTimeout is
+// returned at once. If the backend fails to extend the volume, an
+// ErrVolumeStatus is returned.
+//
+// This only detects completion on the Block Storage side; making the new size
+// visible to a guest the volume is attached to is outside its scope.
+func WaitForExtend(c *gophercloud.ServiceClient, id string, newSize int, secs int) error {
+	return poll(secs, func() (bool, error) {
+		current, err := Get(c, id).Extract()
+		if err != nil {
+			return false, err
+		}
+
+		switch current.Status {
+		case "extending":
+			return false, nil
+		case "error", "error_extending":
+			return false, ErrVolumeStatus{ID: id, Status: current.Status}
+		}
+
+		return current.Size >= newSize, nil
+	})
+}
+
+// WaitForAvailable will continually poll a newly created volume until it is
+// "available", calling onProgress, if set, with the volume's status after each
+// poll. It will do this for up to secs seconds, returning a
+// gophercloud.ErrTimeout if the volume is not available in time; if secs is
+// zero or less, the ErrTimeout is returned at once. The Block Storage service
+// does not report a completion percentage for volumes, so the status is all
+// the progress there is to report.
+//
+// If the volume enters the "error" status, an ErrVolumeStatus is returned. Its
+// Fault is set from the volume's latest user message when the client's
+// Microversion is 3.3 or later, which the Messages API requires.
+func WaitForAvailable(c *gophercloud.ServiceClient, id string, secs int, onProgress func(status string)) error {
+	return poll(secs, func() (bool, error) {
+		current, err := Get(c, id).Extract()
+		if err != nil {
+			return false, err
+		}
+
+		if onProgress != nil {
+			onProgress(current.Status)
+		}
+
+		switch current.Status {
+		case "available":
+			return true, nil
+		case "error":
+			return false, ErrVolumeStatus{ID: id, Status: current.Status, Fault: latestUserMessage(c, id)}
+		}
+
+		return false, nil
+	})
+}
+
+// WaitForMigration will continually poll a volume after a migration to host
+// has been requested until its MigrationStatus is "success" or "error". It
+// will do this for up to secs seconds, returning a gophercloud.ErrTimeout if
+// the migration has not finished in time; if secs is zero or less, the
+// ErrTimeout is returned at once. If the migration fails, an
+// ErrVolumeMigration is returned, with its Fault set as for WaitForAvailable.
+//
+// A volume keeps the MigrationStatus of its last migration, so "success" only
+// counts once the volume is on host. If host is empty, as when the scheduler
+// picks the destination, it counts once the migration has been seen in
+// progress ("starting", "migrating" or "completing"), or once the volume's
+// host differs from the one it was on at the first poll; call
+// WaitForMigration right after volumeactions.Migrate. "error" always ends the
+// wait.
+//
+// The migration status and host are only shown to administrators, so the
+// client must have administrative privileges; otherwise the wait times out.
+//
+// A migrated volume keeps its ID, but it now lives on another backend host,
+// under a new backend name, and possibly with a new volume type after a
+// retype. Re-fetch the volume, and the connection information of any of its
+// attachments, rather than relying on what was retrieved before the move.
+func WaitForMigration(c *gophercloud.ServiceClient, id, host string, secs int) error {
+	var started, polled bool
+	var initialHost string
+	return poll(secs, func() (bool, error) {
+		var current struct {
+			MigrationStatus string `json:"migration_status"`
+			Host            string `json:"os-vol-host-attr:host"`
+		}
+		err := Get(c, id).ExtractInto(&current)
+		if err != nil {
+			return false, err
+		}
+		if !polled {
+			initialHost, polled = current.Host, true
+		}
+
+		switch current.MigrationStatus {
+		case "starting", "migrating", "completing":
+			started = true
+		case "success":
+			if host != "" {
+				return onHost(current.Host, host), nil
+			}
+			return started || current.Host != initialHost, nil
+		case "error":
+			return false, ErrVolumeMigration{ID: id, Fault: latestUserMessage(c, id)}
+		}
+
+		return false, nil
+	})
+}
+
+// onHost reports whether volumeHost, as "host@backend#pool", is on host,
+// which may leave out the pool.
+func onHost(volumeHost, host string) bool {
+	return volumeHost == host || strings.HasPrefix(volumeHost, host+"#")
+}
+
+// latestUserMessage returns the most recent user message recorded for the
+// volume with the provided ID, or an empty string if there is none or it
+// cannot be retrieved.
+func latestUserMessage(c *gophercloud.ServiceClient, id string) string {
+	var s struct {
+		Messages []struct {
+			UserMessage string `json:"user_message"`
+			CreatedAt   string `json:"created_at"`
+		} `json:"messages"`
+	}
+	_, err := c.Get(messagesURL(c)+"?resource_uuid="+url.QueryEscape(id), &s, nil)
+	if err != nil {
+		return ""
+	}
+
+	var latest, latestAt string
+	for _, m := range s.Messages {
+		// Timestamps are ISO 8601 in UTC, so they sort lexically.
+		if m.CreatedAt >= latestAt {
+			latest, latestAt = m.UserMessage, m.CreatedAt
+		}
+	}
+	return latest
+}
+
+// FindVolumesByMetadata returns the volumes whose metadata contains every
+// key-value pair of kv. The volumes are filtered by the Block Storage service
+// where it supports metadata filtering, and the results are checked here as
+// well, since releases that do not support it return all volumes instead.
+func FindVolumesByMetadata(client *gophercloud.ServiceClient, kv map[string]string) ([]Volume, error) {
+	pages, err := List(client, ListOpts{Metadata: kv}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	all, err := ExtractVolumes(pages)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []Volume
+	for _, v := range all {
+		if hasMetadata(v.Metadata, kv) {
+			found = append(found, v)
+		}
+	}
+	return found, nil
+}
+
+// hasMetadata reports whether metadata contains every pair of kv.
+func hasMetadata(metadata, kv map[string]string) bool {
+	for k, v := range kv {
+		if value, ok := metadata[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+// stuckResetStatuses maps each transitional status a volume can get stuck in
+// to the status it is usually reset to: a volume stuck attaching was never
+// attached, one stuck detaching still is, and a volume stuck creating or
+// deleting is put in "error" so that it can be deleted again.
+var stuckResetStatuses = map[string]string{
+	"attaching": "available",
+	"detaching": "in-use",
+	"creating":  "error",
+	"deleting":  "error",
+}
+
+// IsTransitionalStatus reports whether status is one of the transitional
+// statuses ListStuck looks for: "attaching", "detaching", "creating" or
+// "deleting".
+func IsTransitionalStatus(status string) bool {
+	_, ok := stuckResetStatuses[status]
+	return ok
+}
+
+// ListStuckOpts holds options for a ListStuck call.
+type ListStuckOpts struct {
+	// ListOpts are the options the volumes are listed with, e.g. AllTenants.
+	ListOpts ListOpts
+
+	// MinAge is how long a volume must have been in its status, according to
+	// its UpdatedAt, to be considered stuck.
+	MinAge time.Duration
+}
+
+// StuckVolume is a volume found by ListStuck.
+type StuckVolume struct {
+	Volume Volume
+
+	// ResetStatus is the status the volume would usually be reset to, with
+	// volumeactions.ResetStatus.
+	ResetStatus string
+}
+
+// ListStuck lists the volumes that have been in a transitional status, as
+// reported by IsTransitionalStatus, for at least opts.MinAge, along with the
+// status each of them would usually be reset to. A volume that has never been
+// updated is aged from its CreatedAt. It only lists them: nothing is reset,
+// and whether a volume is really stuck, rather than slow, is up to the
+// caller.
+func ListStuck(client *gophercloud.ServiceClient, opts ListStuckOpts) ([]StuckVolume, error) {
+	cutoff := time.Now().Add(-opts.MinAge)
+
+	var stuck []StuckVolume
+	err := List(client, opts.ListOpts).EachPage(func(page pagination.Page) (bool, error) {
+		volumes, err := ExtractVolumes(page)
+		if err != nil {
+			return false, err
+		}
+		for _, v := range volumes {
+			resetStatus, ok := stuckResetStatuses[v.Status]
+			if !ok {
+				continue
+			}
+			since := v.UpdatedAt
+			if since.IsZero() {
+				since = v.CreatedAt
+			}
+			if since.After(cutoff) {
+				continue
+			}
+			stuck = append(stuck, StuckVolume{Volume: v, ResetStatus: resetStatus})
+		}
+		return true, nil
+	})
+	return stuck, err
+}
+
+// ListAll lists the volumes matching opts, like List, but stops once
+// maxItems volumes have been collected. It reports whether more volumes
+// remained: when the last page it needed is used up exactly, it fetches one
+// more page to find out. To avoid fetching much more than is kept, set
+// opts' Limit to at most maxItems. maxItems must be positive.
+func ListAll(client *gophercloud.ServiceClient, opts ListOptsBuilder, maxItems int) ([]Volume, bool, error) {
+	if maxItems < 1 {
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "maxItems"
+		err.Value = maxItems
+		err.Info = "maxItems must be positive"
+		return nil, false, err
+	}
+
+	var all []Volume
+	var more bool
+	err := List(client, opts).EachPage(func(page pagination.Page) (bool, error) {
+		volumes, err := ExtractVolumes(page)
+		if err != nil {
+			return false, err
+		}
+		if n := maxItems - len(all); len(volumes) > n {
+			all = append(all, volumes[:n]...)
+			more = true
+			return false, nil
+		}
+		all = append(all, volumes...)
+		return true, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return all, more, nil
+}
@@ -58,6 +58,43 @@ Example to update a Volume Type
 		panic(err)
 	}
 	fmt.Println(volumetype)
+
+Example to share a private Volume Type with a project
+
+	typeID := "7ffaca22-f646-41d4-b79d-d7e4452ef8cc"
+	projectID := "e1bc8e19d5e1474ba4b4704ea5b56ab6"
+	err := volumetypes.AddProjectAccess(client, typeID, projectID).ExtractErr()
+	if err != nil{
+		panic(err)
+	}
+
+Example to list the projects a private Volume Type is shared with
+
+	allPages, err := volumetypes.ListProjectAccess(client, typeID).AllPages()
+	if err != nil{
+		panic(err)
+	}
+	accesses, err := volumetypes.ExtractProjectAccess(allPages)
+	if err != nil{
+		panic(err)
+	}
+	for _, access := range accesses {
+		fmt.Println(access.ProjectID)
+	}
+
+Example to make a Volume Type an encrypted type
+
+	typeID := "7ffaca22-f646-41d4-b79d-d7e4452ef8cc"
+	encryption, err := volumetypes.CreateEncryption(client, typeID, volumetypes.CreateEncryptionOpts{
+		Provider:        "luks",
+		Cipher:          "aes-xts-plain64",
+		KeySize:         256,
+		ControlLocation: volumetypes.ControlLocationFrontEnd,
+	}).Extract()
+	if err != nil{
+		panic(err)
+	}
+	fmt.Println(encryption.EncryptionID)
 */
 
 package volumetypes
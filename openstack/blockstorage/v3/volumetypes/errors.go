@@ -0,0 +1,18 @@
+package volumetypes
+
+import (
+	"fmt"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+// ErrEncryptionForbidden is the error when the encryption type of a Volume
+// Type is managed by a user who is not an admin.
+type ErrEncryptionForbidden struct {
+	gophercloud.ErrUnexpectedResponseCode
+	VolumeTypeID string
+}
+
+func (e ErrEncryptionForbidden) Error() string {
+	return fmt.Sprintf("Not allowed to manage the encryption type of volume type [%s]; it is admin-only", e.VolumeTypeID)
+}
@@ -136,3 +136,184 @@ func Update(client *gophercloud.ServiceClient, id string, opts UpdateOptsBuilder
 	})
 	return
 }
+
+// ListProjectAccess lists the projects a private Volume Type is shared with.
+// It is admin-only.
+func ListProjectAccess(client *gophercloud.ServiceClient, id string) pagination.Pager {
+	return pagination.NewPager(client, listProjectAccessURL(client, id), func(r pagination.PageResult) pagination.Page {
+		return ProjectAccessPage{pagination.SinglePageBase(r)}
+	})
+}
+
+// AddProjectAccess shares the private Volume Type with the provided ID with a
+// project. It is admin-only.
+func AddProjectAccess(client *gophercloud.ServiceClient, id, projectID string) (r AddProjectAccessResult) {
+	b := map[string]interface{}{
+		"addProjectAccess": map[string]interface{}{"project": projectID},
+	}
+	_, r.Err = client.Post(actionURL(client, id), b, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{202},
+	})
+	return
+}
+
+// RemoveProjectAccess stops sharing the private Volume Type with the provided
+// ID with a project. It is admin-only.
+func RemoveProjectAccess(client *gophercloud.ServiceClient, id, projectID string) (r RemoveProjectAccessResult) {
+	b := map[string]interface{}{
+		"removeProjectAccess": map[string]interface{}{"project": projectID},
+	}
+	_, r.Err = client.Post(actionURL(client, id), b, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{202},
+	})
+	return
+}
+
+// ControlLocation is where the encryption of a volume is done.
+type ControlLocation string
+
+const (
+	// ControlLocationFrontEnd encrypts volumes on the compute host.
+	ControlLocationFrontEnd ControlLocation = "front-end"
+
+	// ControlLocationBackEnd encrypts volumes on the storage backend.
+	ControlLocationBackEnd ControlLocation = "back-end"
+)
+
+// validateControlLocation returns an ErrInvalidInput if location is set and
+// is not a known ControlLocation.
+func validateControlLocation(argument string, location ControlLocation) error {
+	switch location {
+	case "", ControlLocationFrontEnd, ControlLocationBackEnd:
+		return nil
+	}
+	err := gophercloud.ErrInvalidInput{}
+	err.Argument = argument
+	err.Value = location
+	err.Info = "ControlLocation must be front-end or back-end"
+	return err
+}
+
+// CreateEncryptionOptsBuilder allows extensions to add additional parameters
+// to the CreateEncryption request.
+type CreateEncryptionOptsBuilder interface {
+	ToEncryptionCreateMap() (map[string]interface{}, error)
+}
+
+// CreateEncryptionOpts contains options for creating the encryption type of a
+// Volume Type. This object is passed to the volumetypes.CreateEncryption
+// function.
+type CreateEncryptionOpts struct {
+	// Provider is the class that provides encryption support, such as "luks".
+	Provider string `json:"provider" required:"true"`
+	// Cipher is the encryption algorithm or mode, such as "aes-xts-plain64".
+	Cipher string `json:"cipher,omitempty"`
+	// KeySize is the size of the encryption key, in bits.
+	KeySize int `json:"key_size,omitempty"`
+	// ControlLocation is where the encryption is done. It defaults to
+	// ControlLocationFrontEnd.
+	ControlLocation ControlLocation `json:"control_location,omitempty"`
+}
+
+// ToEncryptionCreateMap assembles a request body based on the contents of a
+// CreateEncryptionOpts.
+func (opts CreateEncryptionOpts) ToEncryptionCreateMap() (map[string]interface{}, error) {
+	if err := validateControlLocation("volumetypes.CreateEncryptionOpts.ControlLocation", opts.ControlLocation); err != nil {
+		return nil, err
+	}
+	return gophercloud.BuildRequestBody(opts, "encryption")
+}
+
+// CreateEncryption makes the Volume Type with the provided ID an encrypted
+// type. It is admin-only: for other users the request fails with an
+// ErrEncryptionForbidden. To extract the encryption type from the response,
+// call the Extract method on the CreateEncryptionResult.
+func CreateEncryption(client *gophercloud.ServiceClient, id string, opts CreateEncryptionOptsBuilder) (r CreateEncryptionResult) {
+	b, err := opts.ToEncryptionCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = client.Post(encryptionURL(client, id), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	r.Err = encryptionError(r.Err, id)
+	return
+}
+
+// GetEncryption retrieves the encryption type of the Volume Type with the
+// provided ID. The Volume Type is not encrypted if the extracted encryption
+// type has no EncryptionID. To extract it from the response, call the Extract
+// method on the GetEncryptionResult.
+func GetEncryption(client *gophercloud.ServiceClient, id string) (r GetEncryptionResult) {
+	_, r.Err = client.Get(encryptionURL(client, id), &r.Body, nil)
+	r.Err = encryptionError(r.Err, id)
+	return
+}
+
+// UpdateEncryptionOptsBuilder allows extensions to add additional parameters
+// to the UpdateEncryption request.
+type UpdateEncryptionOptsBuilder interface {
+	ToEncryptionUpdateMap() (map[string]interface{}, error)
+}
+
+// UpdateEncryptionOpts contains options for updating the encryption type of a
+// Volume Type. This object is passed to the volumetypes.UpdateEncryption
+// function. For more information about the parameters, see
+// CreateEncryptionOpts.
+type UpdateEncryptionOpts struct {
+	Provider        string          `json:"provider,omitempty"`
+	Cipher          string          `json:"cipher,omitempty"`
+	KeySize         int             `json:"key_size,omitempty"`
+	ControlLocation ControlLocation `json:"control_location,omitempty"`
+}
+
+// ToEncryptionUpdateMap assembles a request body based on the contents of an
+// UpdateEncryptionOpts.
+func (opts UpdateEncryptionOpts) ToEncryptionUpdateMap() (map[string]interface{}, error) {
+	if err := validateControlLocation("volumetypes.UpdateEncryptionOpts.ControlLocation", opts.ControlLocation); err != nil {
+		return nil, err
+	}
+	return gophercloud.BuildRequestBody(opts, "encryption")
+}
+
+// UpdateEncryption updates the encryption type with the provided encryption
+// ID of the Volume Type with the provided ID. It is admin-only: for other
+// users the request fails with an ErrEncryptionForbidden. The Block Storage
+// service refuses to change the encryption type of a Volume Type that is in
+// use by volumes. Only the updated fields can be extracted from the
+// UpdateEncryptionResult.
+func UpdateEncryption(client *gophercloud.ServiceClient, id, encryptionID string, opts UpdateEncryptionOptsBuilder) (r UpdateEncryptionResult) {
+	b, err := opts.ToEncryptionUpdateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = client.Put(encryptionIDURL(client, id, encryptionID), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	r.Err = encryptionError(r.Err, id)
+	return
+}
+
+// DeleteEncryption deletes the encryption type with the provided encryption
+// ID of the Volume Type with the provided ID, which makes it an unencrypted
+// type. It is admin-only: for other users the request fails with an
+// ErrEncryptionForbidden. The Block Storage service refuses to delete the
+// encryption type of a Volume Type that is in use by volumes.
+func DeleteEncryption(client *gophercloud.ServiceClient, id, encryptionID string) (r DeleteEncryptionResult) {
+	_, r.Err = client.Delete(encryptionIDURL(client, id, encryptionID), &gophercloud.RequestOpts{
+		OkCodes: []int{202},
+	})
+	r.Err = encryptionError(r.Err, id)
+	return
+}
+
+// encryptionError turns a 403 response to an encryption type request for the
+// Volume Type with the provided ID into an ErrEncryptionForbidden.
+func encryptionError(err error, id string) error {
+	if e, ok := err.(gophercloud.ErrDefault403); ok {
+		return ErrEncryptionForbidden{ErrUnexpectedResponseCode: e.ErrUnexpectedResponseCode, VolumeTypeID: id}
+	}
+	return err
+}
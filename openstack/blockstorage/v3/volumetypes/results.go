@@ -23,6 +23,16 @@ type VolumeType struct {
 	PublicAccess bool `json:"os-volume-type-access:is_public"`
 }
 
+// BackendNameSpec is the extra spec naming the backend that volumes of a
+// volume type are scheduled to.
+const BackendNameSpec = "volume_backend_name"
+
+// BackendName returns the backend that volumes of the volume type are
+// scheduled to, or an empty string if the type does not pin a backend.
+func (r VolumeType) BackendName() string {
+	return r.ExtraSpecs[BackendNameSpec]
+}
+
 // VolumeTypePage is a pagination.pager that is returned from a call to the List function.
 type VolumeTypePage struct {
 	pagination.LinkedPageBase
@@ -92,3 +102,110 @@ type DeleteResult struct {
 type UpdateResult struct {
 	commonResult
 }
+
+// ProjectAccess represents a project a private Volume Type is shared with.
+type ProjectAccess struct {
+	// ProjectID is the ID of the project.
+	ProjectID string `json:"project_id"`
+
+	// VolumeTypeID is the ID of the Volume Type.
+	VolumeTypeID string `json:"volume_type_id"`
+}
+
+// ProjectAccessPage is a pagination.Pager that is returned from a call to the
+// ListProjectAccess function.
+type ProjectAccessPage struct {
+	pagination.SinglePageBase
+}
+
+// IsEmpty returns true if a ProjectAccessPage contains no ProjectAccess
+// entries.
+func (r ProjectAccessPage) IsEmpty() (bool, error) {
+	accesses, err := ExtractProjectAccess(r)
+	return len(accesses) == 0, err
+}
+
+// ExtractProjectAccess extracts and returns ProjectAccess entries. It is used
+// while iterating over a volumetypes.ListProjectAccess call.
+func ExtractProjectAccess(r pagination.Page) ([]ProjectAccess, error) {
+	var s struct {
+		ProjectAccess []ProjectAccess `json:"volume_type_access"`
+	}
+	err := (r.(ProjectAccessPage)).ExtractInto(&s)
+	return s.ProjectAccess, err
+}
+
+// AddProjectAccessResult contains the response body and error from an
+// AddProjectAccess request.
+type AddProjectAccessResult struct {
+	gophercloud.ErrResult
+}
+
+// RemoveProjectAccessResult contains the response body and error from a
+// RemoveProjectAccess request.
+type RemoveProjectAccessResult struct {
+	gophercloud.ErrResult
+}
+
+// EncryptionType represents the encryption of the volumes of a Volume Type.
+type EncryptionType struct {
+	// EncryptionID is the ID of the encryption type.
+	EncryptionID string `json:"encryption_id"`
+	// VolumeTypeID is the ID of the Volume Type.
+	VolumeTypeID string `json:"volume_type_id"`
+	// Provider is the class that provides encryption support.
+	Provider string `json:"provider"`
+	// Cipher is the encryption algorithm or mode.
+	Cipher string `json:"cipher"`
+	// KeySize is the size of the encryption key, in bits.
+	KeySize int `json:"key_size"`
+	// ControlLocation is where the encryption is done.
+	ControlLocation ControlLocation `json:"control_location"`
+}
+
+type encryptionResult struct {
+	gophercloud.Result
+}
+
+// Extract will get the encryption type out of the result of a
+// CreateEncryption or UpdateEncryption request.
+func (r encryptionResult) Extract() (*EncryptionType, error) {
+	var s struct {
+		Encryption *EncryptionType `json:"encryption"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Encryption, err
+}
+
+// CreateEncryptionResult contains the response body and error from a
+// CreateEncryption request.
+type CreateEncryptionResult struct {
+	encryptionResult
+}
+
+// UpdateEncryptionResult contains the response body and error from an
+// UpdateEncryption request.
+type UpdateEncryptionResult struct {
+	encryptionResult
+}
+
+// GetEncryptionResult contains the response body and error from a
+// GetEncryption request.
+type GetEncryptionResult struct {
+	gophercloud.Result
+}
+
+// Extract will get the encryption type out of the GetEncryptionResult. Unlike
+// the other encryption type requests, the response is not wrapped in an
+// "encryption" object.
+func (r GetEncryptionResult) Extract() (*EncryptionType, error) {
+	var s EncryptionType
+	err := r.ExtractInto(&s)
+	return &s, err
+}
+
+// DeleteEncryptionResult contains the response body and error from a
+// DeleteEncryption request.
+type DeleteEncryptionResult struct {
+	gophercloud.ErrResult
+}
@@ -152,3 +152,105 @@ func MockUpdateResponse(t *testing.T) {
 }`)
 	})
 }
+
+func MockListProjectAccessResponse(t *testing.T) {
+	th.Mux.HandleFunc("/types/d32019d3-bc6e-4319-9c1d-6722fc136a22/os-volume-type-access", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `
+{
+    "volume_type_access": [
+        {
+            "project_id": "e1bc8e19d5e1474ba4b4704ea5b56ab6",
+            "volume_type_id": "d32019d3-bc6e-4319-9c1d-6722fc136a22"
+        }
+    ]
+}`)
+	})
+}
+
+func MockProjectAccessActionResponse(t *testing.T, action string) {
+	th.Mux.HandleFunc("/types/d32019d3-bc6e-4319-9c1d-6722fc136a22/action", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestJSONRequest(t, r, fmt.Sprintf(`{"%s": {"project": "e1bc8e19d5e1474ba4b4704ea5b56ab6"}}`, action))
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+func MockEncryptionResponse(t *testing.T) {
+	th.Mux.HandleFunc("/types/d32019d3-bc6e-4319-9c1d-6722fc136a22/encryption", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		w.Header().Add("Content-Type", "application/json")
+
+		switch r.Method {
+		case "POST":
+			th.TestJSONRequest(t, r, `
+{
+    "encryption": {
+        "provider": "luks",
+        "cipher": "aes-xts-plain64",
+        "key_size": 256,
+        "control_location": "front-end"
+    }
+}`)
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `
+{
+    "encryption": {
+        "volume_type_id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+        "encryption_id": "81e069c6-7394-4856-8df7-3b237ca61f74",
+        "provider": "luks",
+        "cipher": "aes-xts-plain64",
+        "key_size": 256,
+        "control_location": "front-end"
+    }
+}`)
+		case "GET":
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `
+{
+    "volume_type_id": "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+    "encryption_id": "81e069c6-7394-4856-8df7-3b237ca61f74",
+    "provider": "luks",
+    "cipher": "aes-xts-plain64",
+    "key_size": 256,
+    "control_location": "front-end",
+    "deleted": false,
+    "created_at": "2016-12-28T02:32:25.000000",
+    "updated_at": null,
+    "deleted_at": null
+}`)
+		default:
+			t.Fatalf("Unexpected method: [%s]", r.Method)
+		}
+	})
+
+	th.Mux.HandleFunc("/types/d32019d3-bc6e-4319-9c1d-6722fc136a22/encryption/81e069c6-7394-4856-8df7-3b237ca61f74", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		switch r.Method {
+		case "PUT":
+			th.TestJSONRequest(t, r, `{"encryption": {"control_location": "back-end"}}`)
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"encryption": {"control_location": "back-end"}}`)
+		case "DELETE":
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			t.Fatalf("Unexpected method: [%s]", r.Method)
+		}
+	})
+}
+
+func MockEncryptionForbiddenResponse(t *testing.T) {
+	th.Mux.HandleFunc("/types/d32019d3-bc6e-4319-9c1d-6722fc136a22/encryption", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprintf(w, `{"forbidden": {"message": "Policy doesn't allow volume_extension:volume_type_encryption to be performed.", "code": 403}}`)
+	})
+}
@@ -3,6 +3,7 @@ package testing
 import (
 	"testing"
 
+	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumetypes"
 	"github.com/gophercloud/gophercloud/pagination"
 	th "github.com/gophercloud/gophercloud/testhelper"
@@ -41,6 +42,7 @@ func TestListAll(t *testing.T) {
 			},
 		}
 		th.CheckDeepEquals(t, expected, actual)
+		th.CheckEquals(t, "lvmdriver-1", actual[0].BackendName())
 		return true, nil
 	})
 	th.AssertNoErr(t, err)
@@ -61,6 +63,7 @@ func TestGet(t *testing.T) {
 	th.AssertEquals(t, v.ExtraSpecs["capabilities"], "gpu")
 	th.AssertEquals(t, v.QosSpecID, "d32019d3-bc6e-4319-9c1d-6722fc136a22")
 	th.AssertEquals(t, v.PublicAccess, true)
+	th.AssertEquals(t, "", v.BackendName())
 }
 
 func TestCreate(t *testing.T) {
@@ -116,3 +119,106 @@ func TestUpdate(t *testing.T) {
 	th.CheckEquals(t, "vol-type-002", v.Name)
 	th.CheckEquals(t, true, v.IsPublic)
 }
+
+func TestListProjectAccess(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockListProjectAccessResponse(t)
+
+	allPages, err := volumetypes.ListProjectAccess(client.ServiceClient(), "d32019d3-bc6e-4319-9c1d-6722fc136a22").AllPages()
+	th.AssertNoErr(t, err)
+	actual, err := volumetypes.ExtractProjectAccess(allPages)
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, []volumetypes.ProjectAccess{
+		{
+			ProjectID:    "e1bc8e19d5e1474ba4b4704ea5b56ab6",
+			VolumeTypeID: "d32019d3-bc6e-4319-9c1d-6722fc136a22",
+		},
+	}, actual)
+}
+
+func TestAddProjectAccess(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockProjectAccessActionResponse(t, "addProjectAccess")
+
+	err := volumetypes.AddProjectAccess(client.ServiceClient(), "d32019d3-bc6e-4319-9c1d-6722fc136a22", "e1bc8e19d5e1474ba4b4704ea5b56ab6").ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestRemoveProjectAccess(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockProjectAccessActionResponse(t, "removeProjectAccess")
+
+	err := volumetypes.RemoveProjectAccess(client.ServiceClient(), "d32019d3-bc6e-4319-9c1d-6722fc136a22", "e1bc8e19d5e1474ba4b4704ea5b56ab6").ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestEncryption(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockEncryptionResponse(t)
+
+	typeID := "d32019d3-bc6e-4319-9c1d-6722fc136a22"
+	expected := &volumetypes.EncryptionType{
+		EncryptionID:    "81e069c6-7394-4856-8df7-3b237ca61f74",
+		VolumeTypeID:    typeID,
+		Provider:        "luks",
+		Cipher:          "aes-xts-plain64",
+		KeySize:         256,
+		ControlLocation: volumetypes.ControlLocationFrontEnd,
+	}
+
+	created, err := volumetypes.CreateEncryption(client.ServiceClient(), typeID, volumetypes.CreateEncryptionOpts{
+		Provider:        "luks",
+		Cipher:          "aes-xts-plain64",
+		KeySize:         256,
+		ControlLocation: volumetypes.ControlLocationFrontEnd,
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, expected, created)
+
+	actual, err := volumetypes.GetEncryption(client.ServiceClient(), typeID).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, expected, actual)
+
+	updated, err := volumetypes.UpdateEncryption(client.ServiceClient(), typeID, expected.EncryptionID, volumetypes.UpdateEncryptionOpts{
+		ControlLocation: volumetypes.ControlLocationBackEnd,
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, volumetypes.ControlLocationBackEnd, updated.ControlLocation)
+
+	err = volumetypes.DeleteEncryption(client.ServiceClient(), typeID, expected.EncryptionID).ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestEncryptionInvalidControlLocation(t *testing.T) {
+	_, err := volumetypes.CreateEncryptionOpts{Provider: "luks", ControlLocation: "frontend"}.ToEncryptionCreateMap()
+	if _, ok := err.(gophercloud.ErrInvalidInput); !ok {
+		t.Fatalf("Expected an ErrInvalidInput, got %v", err)
+	}
+
+	_, err = volumetypes.UpdateEncryptionOpts{ControlLocation: "backend"}.ToEncryptionUpdateMap()
+	if _, ok := err.(gophercloud.ErrInvalidInput); !ok {
+		t.Fatalf("Expected an ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestCreateEncryptionForbidden(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	MockEncryptionForbiddenResponse(t)
+
+	err := volumetypes.CreateEncryption(client.ServiceClient(), "d32019d3-bc6e-4319-9c1d-6722fc136a22", volumetypes.CreateEncryptionOpts{
+		Provider: "luks",
+	}).Err
+	if _, ok := err.(volumetypes.ErrEncryptionForbidden); !ok {
+		t.Fatalf("Expected an ErrEncryptionForbidden, got %v", err)
+	}
+}
@@ -21,3 +21,19 @@ func deleteURL(c *gophercloud.ServiceClient, id string) string {
 func updateURL(c *gophercloud.ServiceClient, id string) string {
 	return c.ServiceURL("types", id)
 }
+
+func listProjectAccessURL(c *gophercloud.ServiceClient, id string) string {
+	return c.ServiceURL("types", id, "os-volume-type-access")
+}
+
+func actionURL(c *gophercloud.ServiceClient, id string) string {
+	return c.ServiceURL("types", id, "action")
+}
+
+func encryptionURL(c *gophercloud.ServiceClient, id string) string {
+	return c.ServiceURL("types", id, "encryption")
+}
+
+func encryptionIDURL(c *gophercloud.ServiceClient, id, encryptionID string) string {
+	return c.ServiceURL("types", id, "encryption", encryptionID)
+}
@@ -77,6 +77,10 @@ func AuthenticatedClient(options gophercloud.AuthOptions) (*gophercloud.Provider
 		return nil, err
 	}
 
+	if options.TLSConfig != nil {
+		client.HTTPClient.Transport = newTLSTransport(options.TLSConfig)
+	}
+
 	err = Authenticate(client, options)
 	if err != nil {
 		return nil, err
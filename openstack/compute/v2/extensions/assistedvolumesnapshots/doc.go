@@ -0,0 +1,42 @@
+/*
+Package assistedvolumesnapshots provides the ability to create and delete
+snapshots of volumes on file-based Block Storage backends, such as NFS or
+GlusterFS, with the help of the Compute service.
+
+For a volume on such a backend that is attached to a server, the snapshot
+file has to be created, or merged back on delete, by the hypervisor. The Block
+Storage driver calls these APIs itself, so they are admin-only, and they are
+not a replacement for the regular snapshot API on other backends: the Compute
+service rejects volumes it cannot snapshot with an ErrVolumeNotSupported.
+
+Example to Create an Assisted Volume Snapshot
+
+	createOpts := assistedvolumesnapshots.CreateOpts{
+		VolumeID: "521752a6-acf6-4b2d-bc7a-119f9148cd8c",
+		CreateInfo: assistedvolumesnapshots.CreateInfo{
+			SnapshotID: "421752a6-acf6-4b2d-bc7a-119f9148cd8c",
+			Type:       "qcow2",
+			NewFile:    "volume-521752a6-acf6-4b2d-bc7a-119f9148cd8c.421752a6",
+		},
+	}
+
+	snapshot, err := assistedvolumesnapshots.Create(computeClient, createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Delete an Assisted Volume Snapshot
+
+	deleteOpts := assistedvolumesnapshots.DeleteOpts{
+		VolumeID:        "521752a6-acf6-4b2d-bc7a-119f9148cd8c",
+		FileToMerge:     "volume-521752a6-acf6-4b2d-bc7a-119f9148cd8c.421752a6",
+		MergeTargetFile: "volume-521752a6-acf6-4b2d-bc7a-119f9148cd8c",
+	}
+
+	snapshotID := "421752a6-acf6-4b2d-bc7a-119f9148cd8c"
+	err := assistedvolumesnapshots.Delete(computeClient, snapshotID, deleteOpts).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+*/
+package assistedvolumesnapshots
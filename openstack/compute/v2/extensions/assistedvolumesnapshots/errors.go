@@ -0,0 +1,35 @@
+package assistedvolumesnapshots
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+// ErrVolumeNotSupported is the error when the Compute service refuses an
+// assisted snapshot request for a volume, most commonly because the volume is
+// not on a file-based backend and must be snapshotted with the regular Block
+// Storage API instead. The response body holds the Compute service's reason.
+// Other refusals, such as of a malformed request, remain an ErrDefault400.
+type ErrVolumeNotSupported struct {
+	gophercloud.ErrUnexpectedResponseCode
+	VolumeID string
+}
+
+func (e ErrVolumeNotSupported) Error() string {
+	return fmt.Sprintf("Compute service refused an assisted snapshot of volume [%s]; "+
+		"assisted snapshots are only for file-based backends: %s", e.VolumeID, e.Body)
+}
+
+// volumeNotSupported reports whether err is the Compute service refusing an
+// assisted snapshot because of the volume itself, as opposed to, say, a
+// malformed create_info.
+func volumeNotSupported(err gophercloud.ErrDefault400) bool {
+	fault, ok := gophercloud.ExtractFault(err)
+	if !ok {
+		return false
+	}
+	return strings.HasPrefix(fault.Message, "Invalid volume") ||
+		strings.Contains(fault.Message, "is a multi-attach volume")
+}
@@ -0,0 +1,120 @@
+package assistedvolumesnapshots
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+// CreateOptsBuilder allows extensions to add parameters to the Create request.
+type CreateOptsBuilder interface {
+	ToAssistedVolumeSnapshotCreateMap() (map[string]interface{}, error)
+}
+
+// CreateInfo describes the snapshot file the hypervisor is to create.
+type CreateInfo struct {
+	// SnapshotID is the ID of the Block Storage snapshot.
+	SnapshotID string `json:"snapshot_id" required:"true"`
+
+	// Type is the format of the snapshot file, e.g. "qcow2".
+	Type string `json:"type" required:"true"`
+
+	// NewFile is the name of the snapshot file to create.
+	NewFile string `json:"new_file" required:"true"`
+
+	// ID is an optional identifier of the snapshot file.
+	ID string `json:"id,omitempty"`
+}
+
+// CreateOpts specifies the parameters of an assisted volume snapshot.
+type CreateOpts struct {
+	// VolumeID is the ID of the attached volume to snapshot.
+	VolumeID string `json:"volume_id" required:"true"`
+
+	// CreateInfo describes the snapshot file to create.
+	CreateInfo CreateInfo `json:"create_info" required:"true"`
+}
+
+// ToAssistedVolumeSnapshotCreateMap constructs a request body from CreateOpts.
+func (opts CreateOpts) ToAssistedVolumeSnapshotCreateMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "snapshot")
+}
+
+// Create asks the Compute service to create a snapshot file of an attached
+// volume on a file-based backend.
+func Create(client *gophercloud.ServiceClient, opts CreateOptsBuilder) (r CreateResult) {
+	b, err := opts.ToAssistedVolumeSnapshotCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = client.Post(createURL(client), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if err, ok := r.Err.(gophercloud.ErrDefault400); ok && volumeNotSupported(err) {
+		var volumeID string
+		if snapshot, ok := b["snapshot"].(map[string]interface{}); ok {
+			volumeID, _ = snapshot["volume_id"].(string)
+		}
+		r.Err = ErrVolumeNotSupported{ErrUnexpectedResponseCode: err.ErrUnexpectedResponseCode, VolumeID: volumeID}
+	}
+	return
+}
+
+// DeleteOptsBuilder allows extensions to add parameters to the Delete request.
+type DeleteOptsBuilder interface {
+	ToAssistedVolumeSnapshotDeleteQuery() (string, error)
+}
+
+// DeleteOpts specifies how the snapshot file of an assisted volume snapshot
+// is merged on delete. They are sent JSON-encoded as the delete_info query
+// parameter.
+type DeleteOpts struct {
+	// VolumeID is the ID of the volume the snapshot belongs to.
+	VolumeID string `json:"volume_id" required:"true"`
+
+	// FileToMerge is the snapshot file to merge. It is empty when the
+	// active file itself is deleted.
+	FileToMerge string `json:"file_to_merge,omitempty"`
+
+	// MergeTargetFile is the file FileToMerge is merged into. It is empty
+	// when it is merged into the active file.
+	MergeTargetFile string `json:"merge_target_file,omitempty"`
+}
+
+// ToAssistedVolumeSnapshotDeleteQuery formats a DeleteOpts into a query
+// string.
+func (opts DeleteOpts) ToAssistedVolumeSnapshotDeleteQuery() (string, error) {
+	b, err := gophercloud.BuildRequestBody(opts, "")
+	if err != nil {
+		return "", err
+	}
+	info, err := json.Marshal(b)
+	if err != nil {
+		return "", err
+	}
+	return "?" + url.Values{"delete_info": {string(info)}}.Encode(), nil
+}
+
+// Delete asks the Compute service to delete the snapshot file of an assisted
+// volume snapshot, merging it as described by opts.
+func Delete(client *gophercloud.ServiceClient, snapshotID string, opts DeleteOptsBuilder) (r DeleteResult) {
+	query, err := opts.ToAssistedVolumeSnapshotDeleteQuery()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = client.Delete(deleteURL(client, snapshotID)+query, nil)
+	if err, ok := r.Err.(gophercloud.ErrDefault400); ok && volumeNotSupported(err) {
+		var volumeID string
+		switch o := opts.(type) {
+		case DeleteOpts:
+			volumeID = o.VolumeID
+		case *DeleteOpts:
+			volumeID = o.VolumeID
+		}
+		r.Err = ErrVolumeNotSupported{ErrUnexpectedResponseCode: err.ErrUnexpectedResponseCode, VolumeID: volumeID}
+	}
+	return
+}
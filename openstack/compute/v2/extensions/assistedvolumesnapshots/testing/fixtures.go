@@ -0,0 +1,87 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+// CreateRequest is a sample request to Create.
+const CreateRequest = `
+{
+  "snapshot": {
+    "volume_id": "521752a6-acf6-4b2d-bc7a-119f9148cd8c",
+    "create_info": {
+      "snapshot_id": "421752a6-acf6-4b2d-bc7a-119f9148cd8c",
+      "type": "qcow2",
+      "new_file": "volume-521752a6-acf6-4b2d-bc7a-119f9148cd8c.421752a6"
+    }
+  }
+}
+`
+
+// CreateOutput is a sample response to a Create call.
+const CreateOutput = `
+{
+  "snapshot": {
+    "id": "421752a6-acf6-4b2d-bc7a-119f9148cd8c",
+    "volumeId": "521752a6-acf6-4b2d-bc7a-119f9148cd8c"
+  }
+}
+`
+
+// HandleCreateSuccessfully configures the test server to respond to a Create
+// request.
+func HandleCreateSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/os-assisted-volume-snapshots", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestJSONRequest(t, r, CreateRequest)
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, CreateOutput)
+	})
+}
+
+// HandleCreateNotSupported configures the test server to refuse a Create
+// request the way the Compute service does for a volume on a block backend.
+func HandleCreateNotSupported(t *testing.T) {
+	th.Mux.HandleFunc("/os-assisted-volume-snapshots", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"badRequest": {"code": 400, "message": "Invalid volume: Volume driver iscsi not supported"}}`)
+	})
+}
+
+// HandleCreateBadRequest configures the test server to refuse a Create
+// request whose create_info is malformed.
+func HandleCreateBadRequest(t *testing.T) {
+	th.Mux.HandleFunc("/os-assisted-volume-snapshots", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"badRequest": {"code": 400, "message": "Invalid input for field/attribute create_info. Value: {'type': 'qcow2'}. 'snapshot_id' is a required property"}}`)
+	})
+}
+
+// HandleDeleteSuccessfully configures the test server to respond to a Delete
+// request.
+func HandleDeleteSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/os-assisted-volume-snapshots/421752a6-acf6-4b2d-bc7a-119f9148cd8c", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestFormValues(t, r, map[string]string{
+			"delete_info": `{"file_to_merge":"volume-521752a6-acf6-4b2d-bc7a-119f9148cd8c.421752a6","merge_target_file":"volume-521752a6-acf6-4b2d-bc7a-119f9148cd8c","volume_id":"521752a6-acf6-4b2d-bc7a-119f9148cd8c"}`,
+		})
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,73 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/assistedvolumesnapshots"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+var createOpts = assistedvolumesnapshots.CreateOpts{
+	VolumeID: "521752a6-acf6-4b2d-bc7a-119f9148cd8c",
+	CreateInfo: assistedvolumesnapshots.CreateInfo{
+		SnapshotID: "421752a6-acf6-4b2d-bc7a-119f9148cd8c",
+		Type:       "qcow2",
+		NewFile:    "volume-521752a6-acf6-4b2d-bc7a-119f9148cd8c.421752a6",
+	},
+}
+
+func TestCreate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	HandleCreateSuccessfully(t)
+
+	actual, err := assistedvolumesnapshots.Create(client.ServiceClient(), createOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, &assistedvolumesnapshots.Snapshot{
+		ID:       "421752a6-acf6-4b2d-bc7a-119f9148cd8c",
+		VolumeID: "521752a6-acf6-4b2d-bc7a-119f9148cd8c",
+	}, actual)
+}
+
+func TestCreateNotSupported(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	HandleCreateNotSupported(t)
+
+	_, err := assistedvolumesnapshots.Create(client.ServiceClient(), createOpts).Extract()
+	if err, ok := err.(assistedvolumesnapshots.ErrVolumeNotSupported); !ok {
+		t.Fatalf("Expected ErrVolumeNotSupported, got %v", err)
+	} else {
+		th.AssertEquals(t, "521752a6-acf6-4b2d-bc7a-119f9148cd8c", err.VolumeID)
+	}
+}
+
+func TestCreateBadRequest(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	HandleCreateBadRequest(t)
+
+	_, err := assistedvolumesnapshots.Create(client.ServiceClient(), createOpts).Extract()
+	if _, ok := err.(gophercloud.ErrDefault400); !ok {
+		t.Fatalf("Expected ErrDefault400, got %v", err)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	HandleDeleteSuccessfully(t)
+
+	err := assistedvolumesnapshots.Delete(client.ServiceClient(), "421752a6-acf6-4b2d-bc7a-119f9148cd8c", assistedvolumesnapshots.DeleteOpts{
+		VolumeID:        "521752a6-acf6-4b2d-bc7a-119f9148cd8c",
+		FileToMerge:     "volume-521752a6-acf6-4b2d-bc7a-119f9148cd8c.421752a6",
+		MergeTargetFile: "volume-521752a6-acf6-4b2d-bc7a-119f9148cd8c",
+	}).ExtractErr()
+	th.AssertNoErr(t, err)
+}
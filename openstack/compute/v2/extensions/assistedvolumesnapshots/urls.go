@@ -0,0 +1,13 @@
+package assistedvolumesnapshots
+
+import "github.com/gophercloud/gophercloud"
+
+const resourcePath = "os-assisted-volume-snapshots"
+
+func createURL(c *gophercloud.ServiceClient) string {
+	return c.ServiceURL(resourcePath)
+}
+
+func deleteURL(c *gophercloud.ServiceClient, snapshotID string) string {
+	return c.ServiceURL(resourcePath, snapshotID)
+}
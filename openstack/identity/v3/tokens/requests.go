@@ -52,6 +52,14 @@ type AuthOptions struct {
 	// authentication token ID.
 	TokenID string `json:"-"`
 
+	// Authentication through Application Credentials requires supplying the
+	// secret and either the ID, or the name and the user. Scope must not be
+	// set, as the application credential implies it. See
+	// gophercloud.AuthOptions.
+	ApplicationCredentialID     string `json:"-"`
+	ApplicationCredentialName   string `json:"-"`
+	ApplicationCredentialSecret string `json:"-"`
+
 	Scope Scope `json:"-"`
 }
 
@@ -65,6 +73,10 @@ func (opts *AuthOptions) ToTokenV3CreateMap(scope map[string]interface{}) (map[s
 		DomainName:  opts.DomainName,
 		AllowReauth: opts.AllowReauth,
 		TokenID:     opts.TokenID,
+
+		ApplicationCredentialID:     opts.ApplicationCredentialID,
+		ApplicationCredentialName:   opts.ApplicationCredentialName,
+		ApplicationCredentialSecret: opts.ApplicationCredentialSecret,
 	}
 
 	return gophercloudAuthOpts.ToTokenV3CreateMap(scope)
@@ -78,6 +90,9 @@ func (opts *AuthOptions) ToTokenV3ScopeMap() (map[string]interface{}, error) {
 		Scope:      &scope,
 		DomainID:   opts.DomainID,
 		DomainName: opts.DomainName,
+
+		ApplicationCredentialID:   opts.ApplicationCredentialID,
+		ApplicationCredentialName: opts.ApplicationCredentialName,
 	}
 
 	return gophercloudAuthOpts.ToTokenV3ScopeMap()
@@ -144,6 +144,59 @@ func TestCreateTokenID(t *testing.T) {
 	`)
 }
 
+func TestCreateApplicationCredentialID(t *testing.T) {
+	authTokenPost(t, tokens.AuthOptions{ApplicationCredentialID: "12345abcdef", ApplicationCredentialSecret: "mysecret"}, nil, `
+		{
+			"auth": {
+				"identity": {
+					"methods": ["application_credential"],
+					"application_credential": {
+						"id": "12345abcdef",
+						"secret": "mysecret"
+					}
+				}
+			}
+		}
+	`)
+}
+
+func TestCreateApplicationCredentialNameAndUserID(t *testing.T) {
+	authTokenPost(t, tokens.AuthOptions{ApplicationCredentialName: "myappcred", ApplicationCredentialSecret: "mysecret", UserID: "me"}, nil, `
+		{
+			"auth": {
+				"identity": {
+					"methods": ["application_credential"],
+					"application_credential": {
+						"name": "myappcred",
+						"secret": "mysecret",
+						"user": { "id": "me" }
+					}
+				}
+			}
+		}
+	`)
+}
+
+func TestCreateApplicationCredentialNameAndUsernameDomainName(t *testing.T) {
+	authTokenPost(t, tokens.AuthOptions{ApplicationCredentialName: "myappcred", ApplicationCredentialSecret: "mysecret", Username: "frank", DomainName: "spork.net"}, nil, `
+		{
+			"auth": {
+				"identity": {
+					"methods": ["application_credential"],
+					"application_credential": {
+						"name": "myappcred",
+						"secret": "mysecret",
+						"user": {
+							"name": "frank",
+							"domain": { "name": "spork.net" }
+						}
+					}
+				}
+			}
+		}
+	`)
+}
+
 func TestCreateProjectIDScope(t *testing.T) {
 	options := tokens.AuthOptions{UserID: "fenris", Password: "g0t0h311"}
 	scope := &tokens.Scope{ProjectID: "123456"}
@@ -376,6 +429,31 @@ func TestCreateFailureUserIDDomainName(t *testing.T) {
 	authTokenPostErr(t, options, nil, false, gophercloud.ErrDomainNameWithUserID{})
 }
 
+func TestCreateFailureApplicationCredentialMissingSecret(t *testing.T) {
+	options := tokens.AuthOptions{ApplicationCredentialID: "12345abcdef"}
+	authTokenPostErr(t, options, nil, false, gophercloud.ErrAppCredMissingSecret{})
+}
+
+func TestCreateFailureApplicationCredentialIDAndName(t *testing.T) {
+	options := tokens.AuthOptions{
+		ApplicationCredentialID:     "12345abcdef",
+		ApplicationCredentialName:   "myappcred",
+		ApplicationCredentialSecret: "mysecret",
+	}
+	authTokenPostErr(t, options, nil, false, gophercloud.ErrAppCredIDOrName{})
+}
+
+func TestCreateFailureApplicationCredentialNameMissingUser(t *testing.T) {
+	options := tokens.AuthOptions{ApplicationCredentialName: "myappcred", ApplicationCredentialSecret: "mysecret"}
+	authTokenPostErr(t, options, nil, false, gophercloud.ErrUsernameOrUserID{})
+}
+
+func TestCreateFailureApplicationCredentialScope(t *testing.T) {
+	options := tokens.AuthOptions{ApplicationCredentialID: "12345abcdef", ApplicationCredentialSecret: "mysecret"}
+	scope := &tokens.Scope{ProjectID: "123456"}
+	authTokenPostErr(t, options, scope, false, gophercloud.ErrAppCredWithScope{})
+}
+
 func TestCreateFailureScopeProjectNameAlone(t *testing.T) {
 	options := tokens.AuthOptions{UserID: "myself", Password: "swordfish"}
 	scope := &tokens.Scope{ProjectName: "notenough"}
@@ -0,0 +1,26 @@
+/*
+Package apiversions provides information about the API versions advertised by
+the OpenStack Image service, code-named Glance. The versions are listed from
+the root of the service's endpoint, so this functionality is not restricted to
+a particular version.
+
+The Image service does not use microversions: each minor version of the API
+is advertised as a version of its own, e.g. "v2.9".
+
+Example to List API Versions
+
+	allPages, err := apiversions.List(client).AllPages()
+	if err != nil {
+		panic(err)
+	}
+
+	allVersions, err := apiversions.ExtractAPIVersions(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, version := range allVersions {
+		fmt.Printf("%+v\n", version)
+	}
+*/
+package apiversions
@@ -0,0 +1,48 @@
+package apiversions
+
+import (
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+// Version statuses advertised by the Image service.
+const (
+	StatusCurrent      = "CURRENT"
+	StatusSupported    = "SUPPORTED"
+	StatusExperimental = "EXPERIMENTAL"
+	StatusDeprecated   = "DEPRECATED"
+)
+
+// APIVersion represents an API version for the Image service.
+type APIVersion struct {
+	// ID is the unique identifier of the API version, e.g. "v2.9".
+	ID string `json:"id"`
+
+	// Status is the status of the API version, e.g. StatusCurrent.
+	Status string `json:"status"`
+
+	// Links contains the URL of the API version.
+	Links []gophercloud.Link `json:"links"`
+}
+
+// APIVersionPage is the page returned by a pager when traversing over a
+// collection of API versions.
+type APIVersionPage struct {
+	pagination.SinglePageBase
+}
+
+// IsEmpty checks whether an APIVersionPage struct is empty.
+func (r APIVersionPage) IsEmpty() (bool, error) {
+	is, err := ExtractAPIVersions(r)
+	return len(is) == 0, err
+}
+
+// ExtractAPIVersions takes a collection page, extracts all of the elements,
+// and returns them a slice of APIVersion structs. It is effectively a cast.
+func ExtractAPIVersions(r pagination.Page) ([]APIVersion, error) {
+	var s struct {
+		Versions []APIVersion `json:"versions"`
+	}
+	err := (r.(APIVersionPage)).ExtractInto(&s)
+	return s.Versions, err
+}
@@ -0,0 +1,2 @@
+// apiversions_v2
+package testing
@@ -0,0 +1,66 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/imageservice/apiversions"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+)
+
+const APIListResponse = `
+{
+    "versions": [
+        {
+            "id": "v2.9",
+            "status": "CURRENT",
+            "links": [
+                {
+                    "href": "http://localhost:9292/v2/",
+                    "rel": "self"
+                }
+            ]
+        },
+        {
+            "id": "v2.8",
+            "status": "SUPPORTED",
+            "links": [
+                {
+                    "href": "http://localhost:9292/v2/",
+                    "rel": "self"
+                }
+            ]
+        }
+    ]
+}
+`
+
+var APIListResult = []apiversions.APIVersion{
+	{
+		ID:     "v2.9",
+		Status: apiversions.StatusCurrent,
+		Links:  []gophercloud.Link{{Href: "http://localhost:9292/v2/", Rel: "self"}},
+	},
+	{
+		ID:     "v2.8",
+		Status: apiversions.StatusSupported,
+		Links:  []gophercloud.Link{{Href: "http://localhost:9292/v2/", Rel: "self"}},
+	},
+}
+
+// MockListResponse serves the version list the way Glance does, with a 300
+// Multiple Choices status.
+func MockListResponse(t *testing.T) {
+	th.Mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMultipleChoices)
+
+		fmt.Fprintf(w, APIListResponse)
+	})
+}
@@ -16,6 +16,30 @@ Example to Upload Image Data
 		panic(err)
 	}
 
+Example to Stage and Import Image Data
+
+	imageID := "da3b75d9-3f4a-40e7-8a2c-bfab23927dea"
+
+	imageData, err := os.Open("/path/to/image/file")
+	if err != nil {
+		panic(err)
+	}
+	defer imageData.Close()
+
+	err = imagedata.Stage(imageClient, imageID, imageData).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
+	importOpts := images.ImportOpts{
+		Method: images.ImportMethodGlanceDirect,
+	}
+
+	err = images.Import(imageClient, imageID, importOpts).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
 Example to Download Image Data
 
 	imageID := "da3b75d9-3f4a-40e7-8a2c-bfab23927dea"
@@ -29,5 +53,121 @@ Example to Download Image Data
 	if err != nil {
 		panic(err)
 	}
+
+Example to Stream Image Data to a File
+
+	imageID := "da3b75d9-3f4a-40e7-8a2c-bfab23927dea"
+
+	image, err := imagedata.Download(imageClient, imageID).Extract()
+	if _, ok := err.(images.ErrImageNoData); ok {
+		log.Printf("Image %s has no data yet", imageID)
+		return
+	}
+	if err != nil {
+		panic(err)
+	}
+	defer image.(io.ReadCloser).Close()
+
+	f, err := os.Create("/path/to/image/file")
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, image); err != nil {
+		panic(err)
+	}
+
+Example to Give Up on a Download
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	image, err := imagedata.Download(imageClient.WithContext(ctx), imageID).Extract()
+	if err != nil {
+		panic(err)
+	}
+	defer image.(io.ReadCloser).Close()
+
+	// Copying fails with context.DeadlineExceeded if the transfer is not done
+	// in time, or with context.Canceled once cancel is called.
+	if _, err := io.Copy(f, image); err != nil {
+		panic(err)
+	}
+
+Example to Resume an Interrupted Download
+
+	imageID := "da3b75d9-3f4a-40e7-8a2c-bfab23927dea"
+
+	f, err := os.OpenFile("/path/to/image/file", os.O_WRONLY, 0644)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	offset, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		panic(err)
+	}
+
+	res := imagedata.DownloadFrom(imageClient, imageID, offset)
+	image, err := res.Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	if !res.RangeHonored() {
+		// The whole image was sent, so start the file over.
+		if err := f.Truncate(0); err != nil {
+			panic(err)
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			panic(err)
+		}
+	}
+
+	_, err = io.Copy(f, image)
+	if err != nil {
+		panic(err)
+	}
+
+Example to Resume an Interrupted Download and Verify the Whole Image
+
+	imageID := "da3b75d9-3f4a-40e7-8a2c-bfab23927dea"
+
+	f, err := os.OpenFile("/path/to/image/file", os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	res, err := imagedata.ResumeDownloadVerified(imageClient, imageID, f)
+	if err != nil {
+		panic(err)
+	}
+	if res.RehashErr != nil {
+		log.Printf("Downloaded the whole image again: %s", res.RehashErr)
+	}
+
+Example to Export an Image as a Tar Bundle
+
+	imageID := "da3b75d9-3f4a-40e7-8a2c-bfab23927dea"
+
+	bundle, err := imagedata.Export(imageClient, imageID)
+	if err != nil {
+		panic(err)
+	}
+	defer bundle.Close()
+
+	f, err := os.Create("/path/to/image.tar")
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, bundle)
+	if err != nil {
+		panic(err)
+	}
 */
 package imagedata
@@ -1,10 +1,16 @@
 package imagedata
 
 import (
+	"bytes"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/imageservice/v2/images"
 )
 
 // Upload uploads an image file.
@@ -16,13 +22,140 @@ func Upload(client *gophercloud.ServiceClient, id string, data io.Reader) (r Upl
 	return
 }
 
-// Download retrieves an image.
+// Stage uploads image data to the staging area of the Image service, to be
+// imported with images.Import and images.ImportMethodGlanceDirect.
+func Stage(client *gophercloud.ServiceClient, id string, data io.Reader) (r StageResult) {
+	_, r.Err = client.Put(stageURL(client, id), data, nil, &gophercloud.RequestOpts{
+		MoreHeaders: map[string]string{"Content-Type": "application/octet-stream"},
+		OkCodes:     []int{204},
+	})
+	return
+}
+
+// Download retrieves an image. The data is not read into the result: it is
+// streamed from the response body, which the caller must close after reading
+// it. If no data has been uploaded for the image yet, an images.ErrImageNoData
+// is returned.
 func Download(client *gophercloud.ServiceClient, id string) (r DownloadResult) {
 	var resp *http.Response
-	resp, r.Err = client.Get(downloadURL(client, id), nil, nil)
-	if resp != nil {
+	resp, r.Err = client.Get(downloadURL(client, id), nil, &gophercloud.RequestOpts{
+		OkCodes: []int{200, 204},
+	})
+	if resp == nil {
+		return
+	}
+
+	r.Header = resp.Header
+	if resp.StatusCode == http.StatusNoContent {
+		resp.Body.Close()
+		r.Err = images.ErrImageNoData{ImageID: id}
+		return
+	}
+	r.Body = resp.Body
+	return
+}
+
+// DownloadFrom retrieves an image's data starting at the byte offset, so that
+// an interrupted Download can be resumed. The server may ignore the requested
+// range and send the whole image instead; call RangeHonored on the result to
+// find out which happened before appending the data to a partial file.
+//
+// If offset is the size of the image, the download was already complete and
+// the server refuses the range with a 416; this is reported as an honored
+// range with no data.
+func DownloadFrom(client *gophercloud.ServiceClient, id string, offset int64) (r DownloadResult) {
+	url := downloadURL(client, id)
+	var resp *http.Response
+	resp, r.Err = client.Get(url, nil, &gophercloud.RequestOpts{
+		MoreHeaders: map[string]string{"Range": fmt.Sprintf("bytes=%d-", offset)},
+		OkCodes:     []int{200, 206, 416},
+	})
+	if resp == nil {
+		return
+	}
+	r.Header = resp.Header
+
+	if resp.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		// The size is given as "bytes */size", if at all.
+		size, err := strconv.ParseInt(strings.TrimPrefix(resp.Header.Get("Content-Range"), "bytes */"), 10, 64)
+		if err != nil {
+			image, err := images.Get(client, id).Extract()
+			if err != nil {
+				r.Err = err
+				return
+			}
+			size = image.SizeBytes
+		}
+		if offset != size {
+			r.Err = gophercloud.ErrUnexpectedResponseCode{
+				URL:      url,
+				Method:   "GET",
+				Expected: []int{200, 206},
+				Actual:   resp.StatusCode,
+				Body:     body,
+			}
+			return
+		}
+
+		r.Body = ioutil.NopCloser(bytes.NewReader(nil))
+		r.rangeHonored = true
+		return
+	}
+
+	r.Body = resp.Body
+	r.rangeHonored = resp.StatusCode == http.StatusPartialContent || offset == 0
+	return
+}
+
+// DownloadIfNoneMatch retrieves an image's data unless its ETag matches etag,
+// typically the ETag of a copy downloaded earlier. Call NotModified on the
+// result to find out whether the data was sent.
+//
+// ETags are compared with the weak comparison of RFC 7232, so that W/"abc"
+// and "abc" match. Some proxies in front of the Image service return weak
+// ETags and compare If-None-Match strictly, sending the data again; such a
+// response is also reported as not modified, and its data discarded.
+func DownloadIfNoneMatch(client *gophercloud.ServiceClient, id string, etag string) (r DownloadResult) {
+	var resp *http.Response
+	resp, r.Err = client.Get(downloadURL(client, id), nil, &gophercloud.RequestOpts{
+		MoreHeaders: map[string]string{"If-None-Match": etag},
+		OkCodes:     []int{200, 304},
+	})
+	if resp == nil {
+		return
+	}
+
+	r.Header = resp.Header
+	r.notModified = resp.StatusCode == http.StatusNotModified ||
+		etagMatches(etag, resp.Header.Get("ETag"))
+	if r.notModified {
+		resp.Body.Close()
+		r.Body = http.NoBody
+	} else {
 		r.Body = resp.Body
-		r.Header = resp.Header
 	}
 	return
 }
+
+// etagMatches reports whether etag matches any entry of the If-None-Match
+// list ifNoneMatch, using weak comparison.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if etag == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || opaqueTag(candidate) == opaqueTag(etag) {
+			return true
+		}
+	}
+	return false
+}
+
+// opaqueTag returns etag without its weakness indicator.
+func opaqueTag(etag string) string {
+	return strings.TrimPrefix(strings.TrimSpace(etag), "W/")
+}
@@ -13,14 +13,39 @@ type UploadResult struct {
 	gophercloud.ErrResult
 }
 
+// StageResult is the result of a stage image operation. Call its ExtractErr
+// method to determine if the request succeeded or failed.
+type StageResult struct {
+	gophercloud.ErrResult
+}
+
 // DownloadResult is the result of a download image operation. Call its Extract
 // method to gain access to the image data.
 type DownloadResult struct {
 	gophercloud.Result
+
+	rangeHonored bool
+	notModified  bool
+}
+
+// NotModified reports whether DownloadIfNoneMatch found the image's ETag
+// unchanged, in which case no data was returned.
+func (r DownloadResult) NotModified() bool {
+	return r.notModified
+}
+
+// RangeHonored reports whether the data returned by DownloadFrom starts at the
+// requested offset. If it is false, the server ignored the range and the data
+// starts from the beginning of the image.
+func (r DownloadResult) RangeHonored() bool {
+	return r.rangeHonored
 }
 
 // Extract builds images model from io.Reader
 func (r DownloadResult) Extract() (io.Reader, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
 	if r, ok := r.Body.(io.Reader); ok {
 		return r, nil
 	}
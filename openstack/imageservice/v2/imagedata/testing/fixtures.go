@@ -1,6 +1,7 @@
 package testing
 
 import (
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"testing"
@@ -26,6 +27,24 @@ func HandlePutImageDataSuccessfully(t *testing.T) {
 	})
 }
 
+// HandleStageImageDataSuccessfully setup
+func HandleStageImageDataSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/images/da3b75d9-3f4a-40e7-8a2c-bfab23927dea/stage", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PUT")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/octet-stream")
+
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("Unable to read request body: %v", err)
+		}
+
+		th.AssertByteArrayEquals(t, []byte{5, 3, 7, 24}, b)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
 // HandleGetImageDataSuccessfully setup
 func HandleGetImageDataSuccessfully(t *testing.T) {
 	th.Mux.HandleFunc("/images/da3b75d9-3f4a-40e7-8a2c-bfab23927dea/file", func(w http.ResponseWriter, r *http.Request) {
@@ -38,3 +57,191 @@ func HandleGetImageDataSuccessfully(t *testing.T) {
 		th.AssertNoErr(t, err)
 	})
 }
+
+// HandleGetImageDataNoContent setup for a queued image without data.
+func HandleGetImageDataNoContent(t *testing.T) {
+	th.Mux.HandleFunc("/images/da3b75d9-3f4a-40e7-8a2c-bfab23927dea/file", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// HandleGetImageDataStalled setup. The handler sends the start of the image
+// data and then stalls until the client goes away.
+func HandleGetImageDataStalled(t *testing.T) {
+	th.Mux.HandleFunc("/images/da3b75d9-3f4a-40e7-8a2c-bfab23927dea/file", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		w.WriteHeader(http.StatusOK)
+
+		_, err := w.Write([]byte{34, 87, 0, 23})
+		th.AssertNoErr(t, err)
+		w.(http.Flusher).Flush()
+
+		<-r.Context().Done()
+	})
+}
+
+// HandleGetImageDataConditionalSuccessfully setup. The handler serves the
+// image with the given ETag and, like a strict proxy, only answers 304 when
+// If-None-Match is byte-for-byte equal to it.
+func HandleGetImageDataConditionalSuccessfully(t *testing.T, etag string) {
+	th.Mux.HandleFunc("/images/da3b75d9-3f4a-40e7-8a2c-bfab23927dea/file", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+
+		_, err := w.Write([]byte{34, 87, 0, 23, 23, 23, 56, 255, 254, 0})
+		th.AssertNoErr(t, err)
+	})
+}
+
+// HandleGetImageDataRangeSuccessfully setup
+func HandleGetImageDataRangeSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/images/da3b75d9-3f4a-40e7-8a2c-bfab23927dea/file", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+		th.TestHeader(t, r, "Range", "bytes=6-")
+
+		w.Header().Set("Content-Range", "bytes 6-9/10")
+		w.WriteHeader(http.StatusPartialContent)
+
+		_, err := w.Write([]byte{56, 255, 254, 0})
+		th.AssertNoErr(t, err)
+	})
+}
+
+// HandleGetImageDataRangeNotSatisfiable setup. The Content-Range header,
+// which gives the image size, is left out if contentRange is empty.
+func HandleGetImageDataRangeNotSatisfiable(t *testing.T, contentRange string) {
+	th.Mux.HandleFunc("/images/da3b75d9-3f4a-40e7-8a2c-bfab23927dea/file", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		if contentRange != "" {
+			w.Header().Set("Content-Range", contentRange)
+		}
+		w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+	})
+}
+
+// HandleGetImageSuccessfully setup
+func HandleGetImageSuccessfully(t *testing.T, size int) {
+	th.Mux.HandleFunc("/images/da3b75d9-3f4a-40e7-8a2c-bfab23927dea", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{
+			"id": "da3b75d9-3f4a-40e7-8a2c-bfab23927dea",
+			"name": "cirros",
+			"status": "active",
+			"container_format": "bare",
+			"disk_format": "qcow2",
+			"min_disk": 1,
+			"min_ram": 64,
+			"tags": ["base"],
+			"checksum": "64d7c1cd2b6f60c92c14662941cb7913",
+			"size": %d,
+			"hw_disk_bus": "scsi"
+		}`, size)
+	})
+}
+
+// HandleGetImageWithChecksumSuccessfully setup for the image whose data is
+// served by HandleGetImageDataSuccessfully. Its os_hash_algo and
+// os_hash_value are only reported if osHashAlgo is set.
+func HandleGetImageWithChecksumSuccessfully(t *testing.T, osHashAlgo string) {
+	th.Mux.HandleFunc("/images/da3b75d9-3f4a-40e7-8a2c-bfab23927dea", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		multihash := ""
+		if osHashAlgo != "" {
+			multihash = fmt.Sprintf(`"os_hash_algo": "%s", "os_hash_value": "%s",`, osHashAlgo, imageDataSHA512)
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{
+			"id": "da3b75d9-3f4a-40e7-8a2c-bfab23927dea",
+			"name": "cirros",
+			"status": "active",
+			%s
+			"checksum": "f1bcbf6689fd908c2c3b06a79f4a9d96",
+			"size": 10
+		}`, multihash)
+	})
+}
+
+// imageDataSHA512 is the sha512 hexdigest of the data served by
+// HandleGetImageDataSuccessfully.
+var imageDataSHA512 = "e83450c9b6b18687e275bea11586bfa61b461356e0ea84ce0fc0d4da445a105418291702761f7f134438f9c94cf8d2bf04f92c3c769334dfb0f70d6006918d5a"
+
+// osHashValue is the sha512 hexdigest of the data uploaded by
+// HandleCreateWithDataSuccessfully, as reported once the image is active.
+var osHashValue = "adb01193e8b872c4cf214525aa080764be7b511a9556df6c6f5d0bd0853a5d309fb76e0912060f0381916f3ad26350a696da94620dd55135d67d560d640d264f"
+
+// HandleCreateWithDataSuccessfully setup. The upload fails if uploadStatus is
+// not 204, and deleted is set if the image is deleted. Once uploaded, the
+// image has each of statuses in turn, repeating the last one, or is active if
+// there are none.
+func HandleCreateWithDataSuccessfully(t *testing.T, uploadStatus int, deleted *bool, statuses ...string) {
+	if len(statuses) == 0 {
+		statuses = []string{"active"}
+	}
+	var gets int
+
+	th.Mux.HandleFunc("/images", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+		th.TestJSONRequest(t, r, `{"name": "cirros", "disk_format": "qcow2", "container_format": "bare"}`)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"id": "da3b75d9-3f4a-40e7-8a2c-bfab23927dea", "name": "cirros", "status": "queued"}`)
+	})
+
+	th.Mux.HandleFunc("/images/da3b75d9-3f4a-40e7-8a2c-bfab23927dea/file", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PUT")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/octet-stream")
+
+		b, err := ioutil.ReadAll(r.Body)
+		th.AssertNoErr(t, err)
+		th.AssertByteArrayEquals(t, []byte{5, 3, 7, 24}, b)
+		w.WriteHeader(uploadStatus)
+	})
+
+	th.Mux.HandleFunc("/images/da3b75d9-3f4a-40e7-8a2c-bfab23927dea", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		switch r.Method {
+		case "GET":
+			status := statuses[len(statuses)-1]
+			if gets < len(statuses) {
+				status = statuses[gets]
+			}
+			gets++
+
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"id": "da3b75d9-3f4a-40e7-8a2c-bfab23927dea", "name": "cirros", "status": "%s", "size": 4, "os_hash_algo": "sha512", "os_hash_value": "%s"}`, status, osHashValue)
+		case "DELETE":
+			*deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("Unexpected method: %s", r.Method)
+		}
+	})
+}
@@ -1,12 +1,21 @@
 package testing
 
 import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/imageservice/v2/imagedata"
+	"github.com/gophercloud/gophercloud/openstack/imageservice/v2/images"
 	th "github.com/gophercloud/gophercloud/testhelper"
 	fakeclient "github.com/gophercloud/gophercloud/testhelper/client"
 )
@@ -25,6 +34,20 @@ func TestUpload(t *testing.T) {
 	th.AssertNoErr(t, err)
 }
 
+func TestStage(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	HandleStageImageDataSuccessfully(t)
+
+	err := imagedata.Stage(
+		fakeclient.ServiceClient(),
+		"da3b75d9-3f4a-40e7-8a2c-bfab23927dea",
+		readSeekerOfBytes([]byte{5, 3, 7, 24})).ExtractErr()
+
+	th.AssertNoErr(t, err)
+}
+
 func readSeekerOfBytes(bs []byte) io.ReadSeeker {
 	return &RS{bs: bs}
 }
@@ -85,3 +108,444 @@ func TestDownload(t *testing.T) {
 
 	th.AssertByteArrayEquals(t, []byte{34, 87, 0, 23, 23, 23, 56, 255, 254, 0}, bs)
 }
+
+func TestDownloadNoData(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	HandleGetImageDataNoContent(t)
+
+	_, err := imagedata.Download(fakeclient.ServiceClient(), "da3b75d9-3f4a-40e7-8a2c-bfab23927dea").Extract()
+	if e, ok := err.(images.ErrImageNoData); !ok || e.ImageID != "da3b75d9-3f4a-40e7-8a2c-bfab23927dea" {
+		t.Fatalf("Expected ErrImageNoData, got %v", err)
+	}
+}
+
+func TestDownloadCancelled(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	HandleGetImageDataStalled(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rdr, err := imagedata.Download(fakeclient.ServiceClient().WithContext(ctx), "da3b75d9-3f4a-40e7-8a2c-bfab23927dea").Extract()
+	th.AssertNoErr(t, err)
+
+	bs := make([]byte, 4)
+	_, err = io.ReadFull(rdr, bs)
+	th.AssertNoErr(t, err)
+	th.AssertByteArrayEquals(t, []byte{34, 87, 0, 23}, bs)
+
+	cancel()
+	if _, err := ioutil.ReadAll(rdr); err != context.Canceled {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestDownloadFrom(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	HandleGetImageDataRangeSuccessfully(t)
+
+	res := imagedata.DownloadFrom(fakeclient.ServiceClient(), "da3b75d9-3f4a-40e7-8a2c-bfab23927dea", 6)
+	rdr, err := res.Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, res.RangeHonored())
+
+	bs, err := ioutil.ReadAll(rdr)
+	th.AssertNoErr(t, err)
+
+	th.AssertByteArrayEquals(t, []byte{56, 255, 254, 0}, bs)
+}
+
+func TestDownloadFromRangeIgnored(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	HandleGetImageDataSuccessfully(t)
+
+	res := imagedata.DownloadFrom(fakeclient.ServiceClient(), "da3b75d9-3f4a-40e7-8a2c-bfab23927dea", 6)
+	rdr, err := res.Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, false, res.RangeHonored())
+
+	bs, err := ioutil.ReadAll(rdr)
+	th.AssertNoErr(t, err)
+
+	th.AssertByteArrayEquals(t, []byte{34, 87, 0, 23, 23, 23, 56, 255, 254, 0}, bs)
+}
+
+func TestDownloadFromComplete(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	HandleGetImageDataRangeNotSatisfiable(t, "bytes */10")
+
+	res := imagedata.DownloadFrom(fakeclient.ServiceClient(), "da3b75d9-3f4a-40e7-8a2c-bfab23927dea", 10)
+	rdr, err := res.Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, res.RangeHonored())
+
+	bs, err := ioutil.ReadAll(rdr)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 0, len(bs))
+
+	_, err = imagedata.DownloadFrom(fakeclient.ServiceClient(), "da3b75d9-3f4a-40e7-8a2c-bfab23927dea", 12).Extract()
+	if err, ok := err.(gophercloud.ErrUnexpectedResponseCode); !ok || err.Actual != http.StatusRequestedRangeNotSatisfiable {
+		t.Fatalf("Expected a 416 ErrUnexpectedResponseCode, got %v", err)
+	}
+}
+
+func TestDownloadFromCompleteWithoutContentRange(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	HandleGetImageSuccessfully(t, 10)
+	HandleGetImageDataRangeNotSatisfiable(t, "")
+
+	res := imagedata.DownloadFrom(fakeclient.ServiceClient(), "da3b75d9-3f4a-40e7-8a2c-bfab23927dea", 10)
+	_, err := res.Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, res.RangeHonored())
+}
+
+func TestExport(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	HandleGetImageSuccessfully(t, 10)
+	HandleGetImageDataSuccessfully(t)
+
+	rc, err := imagedata.Export(fakeclient.ServiceClient(), "da3b75d9-3f4a-40e7-8a2c-bfab23927dea")
+	th.AssertNoErr(t, err)
+	defer rc.Close()
+
+	tr := tar.NewReader(rc)
+
+	hdr, err := tr.Next()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, imagedata.ExportManifestName, hdr.Name)
+	var manifest imagedata.ExportManifest
+	th.AssertNoErr(t, json.NewDecoder(tr).Decode(&manifest))
+	th.AssertDeepEquals(t, imagedata.ExportManifest{
+		ID:              "da3b75d9-3f4a-40e7-8a2c-bfab23927dea",
+		Name:            "cirros",
+		ContainerFormat: "bare",
+		DiskFormat:      "qcow2",
+		MinDisk:         1,
+		MinRAM:          64,
+		Tags:            []string{"base"},
+		Properties:      map[string]interface{}{"hw_disk_bus": "scsi"},
+		Checksum:        "64d7c1cd2b6f60c92c14662941cb7913",
+		Size:            10,
+		DiskFile:        "disk.qcow2",
+	}, manifest)
+
+	hdr, err = tr.Next()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "disk.qcow2", hdr.Name)
+	bs, err := ioutil.ReadAll(tr)
+	th.AssertNoErr(t, err)
+	th.AssertByteArrayEquals(t, []byte{34, 87, 0, 23, 23, 23, 56, 255, 254, 0}, bs)
+
+	_, err = tr.Next()
+	th.AssertEquals(t, io.EOF, err)
+}
+
+func TestExportSizeMismatch(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	HandleGetImageSuccessfully(t, 20)
+	HandleGetImageDataSuccessfully(t)
+
+	rc, err := imagedata.Export(fakeclient.ServiceClient(), "da3b75d9-3f4a-40e7-8a2c-bfab23927dea")
+	th.AssertNoErr(t, err)
+	defer rc.Close()
+
+	_, err = ioutil.ReadAll(rc)
+	if err == nil {
+		t.Fatal("Expected an error for truncated image data")
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestDownloadTee(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	HandleGetImageDataSuccessfully(t)
+
+	var a, b bytes.Buffer
+	err := imagedata.DownloadTee(fakeclient.ServiceClient(), "da3b75d9-3f4a-40e7-8a2c-bfab23927dea", imagedata.DownloadTeeOpts{}, &a, &b)
+	th.AssertNoErr(t, err)
+	th.AssertByteArrayEquals(t, []byte{34, 87, 0, 23, 23, 23, 56, 255, 254, 0}, a.Bytes())
+	th.AssertByteArrayEquals(t, []byte{34, 87, 0, 23, 23, 23, 56, 255, 254, 0}, b.Bytes())
+}
+
+func TestDownloadTeeWriterFails(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	HandleGetImageDataSuccessfully(t)
+
+	var b bytes.Buffer
+	err := imagedata.DownloadTee(fakeclient.ServiceClient(), "da3b75d9-3f4a-40e7-8a2c-bfab23927dea", imagedata.DownloadTeeOpts{}, failingWriter{}, &b)
+	if e, ok := err.(imagedata.ErrDownloadTee); !ok {
+		t.Fatalf("Expected ErrDownloadTee, got %v", err)
+	} else {
+		th.AssertEquals(t, 1, len(e.Errors))
+		th.AssertEquals(t, "disk full", e.Errors[0].Error())
+	}
+	th.AssertByteArrayEquals(t, []byte{34, 87, 0, 23, 23, 23, 56, 255, 254, 0}, b.Bytes())
+}
+
+func TestDownloadIfNoneMatch(t *testing.T) {
+	for _, tc := range []struct {
+		serverETag, clientETag string
+		notModified            bool
+	}{
+		{`"abc"`, `"abc"`, true},
+		{`W/"abc"`, `W/"abc"`, true},
+		{`W/"abc"`, `"abc"`, true},
+		{`"abc"`, `W/"abc"`, true},
+		{`W/"abc"`, `"def", W/"abc"`, true},
+		{`"abc"`, `*`, true},
+		{`"abc"`, `"def"`, false},
+		{`W/"abc"`, `W/"def"`, false},
+	} {
+		th.SetupHTTP()
+		HandleGetImageDataConditionalSuccessfully(t, tc.serverETag)
+
+		result := imagedata.DownloadIfNoneMatch(fakeclient.ServiceClient(), "da3b75d9-3f4a-40e7-8a2c-bfab23927dea", tc.clientETag)
+		th.AssertNoErr(t, result.Err)
+		th.AssertEquals(t, tc.notModified, result.NotModified())
+
+		rdr, err := result.Extract()
+		th.AssertNoErr(t, err)
+		bs, err := ioutil.ReadAll(rdr)
+		th.AssertNoErr(t, err)
+		if tc.notModified {
+			th.AssertEquals(t, 0, len(bs))
+		} else {
+			th.AssertByteArrayEquals(t, []byte{34, 87, 0, 23, 23, 23, 56, 255, 254, 0}, bs)
+		}
+		th.TeardownHTTP()
+	}
+}
+
+func TestCreateWithData(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var deleted bool
+	HandleCreateWithDataSuccessfully(t, http.StatusNoContent, &deleted, "saving", "active")
+
+	image, err := imagedata.CreateWithData(fakeclient.ServiceClient(), images.CreateOpts{
+		Name:            "cirros",
+		DiskFormat:      "qcow2",
+		ContainerFormat: "bare",
+	}, bytes.NewReader([]byte{5, 3, 7, 24}), 5)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, images.ImageStatusActive, image.Status)
+	th.AssertEquals(t, false, deleted)
+}
+
+func TestCreateWithDataKilled(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var deleted bool
+	HandleCreateWithDataSuccessfully(t, http.StatusNoContent, &deleted, "killed")
+
+	_, err := imagedata.CreateWithData(fakeclient.ServiceClient(), images.CreateOpts{
+		Name:            "cirros",
+		DiskFormat:      "qcow2",
+		ContainerFormat: "bare",
+	}, bytes.NewReader([]byte{5, 3, 7, 24}), 5)
+	if err, ok := err.(images.ErrImageStatus); !ok {
+		t.Fatalf("Expected ErrImageStatus, got %v", err)
+	} else {
+		th.AssertEquals(t, images.ImageStatusKilled, err.Status)
+	}
+	th.AssertEquals(t, true, deleted)
+}
+
+func TestCreateWithDataInvalidTimeout(t *testing.T) {
+	_, err := imagedata.CreateWithData(fakeclient.ServiceClient(), images.CreateOpts{Name: "cirros"}, bytes.NewReader(nil), 0)
+	if _, ok := err.(gophercloud.ErrInvalidInput); !ok {
+		t.Fatalf("Expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestCreateWithDataUploadFails(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var deleted bool
+	HandleCreateWithDataSuccessfully(t, http.StatusInternalServerError, &deleted)
+
+	_, err := imagedata.CreateWithData(fakeclient.ServiceClient(), images.CreateOpts{
+		Name:            "cirros",
+		DiskFormat:      "qcow2",
+		ContainerFormat: "bare",
+	}, bytes.NewReader([]byte{5, 3, 7, 24}), 5)
+	if _, ok := err.(gophercloud.ErrDefault500); !ok {
+		t.Fatalf("Expected ErrDefault500, got %v", err)
+	}
+	th.AssertEquals(t, true, deleted)
+}
+
+func TestCreateWithVerifiedData(t *testing.T) {
+	opts := images.CreateOpts{
+		Name:            "cirros",
+		DiskFormat:      "qcow2",
+		ContainerFormat: "bare",
+	}
+
+	cases := []struct {
+		algo, value string
+		match       bool
+	}{
+		{"sha512", osHashValue, true},
+		{"SHA256", "01172cd83b44e729119d73759d62295925a5a54e44b7e0717dc213ba0b52b456", true},
+		{"sha512", strings.Repeat("0", 128), false},
+	}
+
+	for _, c := range cases {
+		th.SetupHTTP()
+
+		var deleted bool
+		HandleCreateWithDataSuccessfully(t, http.StatusNoContent, &deleted)
+
+		image, err := imagedata.CreateWithVerifiedData(fakeclient.ServiceClient(), opts, bytes.NewReader([]byte{5, 3, 7, 24}), c.algo, c.value, 5)
+		if c.match {
+			th.AssertNoErr(t, err)
+			th.AssertEquals(t, images.ImageStatusActive, image.Status)
+		} else {
+			mismatch, ok := err.(images.ErrImageHashMismatch)
+			if !ok {
+				t.Fatalf("Expected ErrImageHashMismatch, got %v", err)
+			}
+			th.AssertEquals(t, "da3b75d9-3f4a-40e7-8a2c-bfab23927dea", mismatch.ImageID)
+			th.AssertEquals(t, osHashValue, mismatch.Actual)
+		}
+		th.AssertEquals(t, !c.match, deleted)
+
+		th.TeardownHTTP()
+	}
+}
+
+func TestCreateWithVerifiedDataUnsupportedAlgorithm(t *testing.T) {
+	_, err := imagedata.CreateWithVerifiedData(fakeclient.ServiceClient(), images.CreateOpts{Name: "cirros"}, bytes.NewReader(nil), "crc32", "00", 5)
+	if _, ok := err.(gophercloud.ErrInvalidInput); !ok {
+		t.Fatalf("Expected ErrInvalidInput, got %v", err)
+	}
+}
+
+// partialFile is an in-memory imagedata.PartialFile. If failRead is set,
+// reading it fails.
+type partialFile struct {
+	data     []byte
+	pos      int64
+	failRead bool
+}
+
+func (f *partialFile) Read(p []byte) (int, error) {
+	if f.failRead {
+		return 0, errors.New("read failed")
+	}
+	if f.pos >= int64(len(f.data)) {
+		return 0, io.EOF
+	}
+	n := copy(p, f.data[f.pos:])
+	f.pos += int64(n)
+	return n, nil
+}
+
+func (f *partialFile) Write(p []byte) (int, error) {
+	f.data = append(f.data[:f.pos], p...)
+	f.pos += int64(len(p))
+	return len(p), nil
+}
+
+func (f *partialFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+		f.pos = offset
+	case io.SeekEnd:
+		f.pos = int64(len(f.data)) + offset
+	default:
+		f.pos += offset
+	}
+	return f.pos, nil
+}
+
+func (f *partialFile) Truncate(size int64) error {
+	f.data = f.data[:size]
+	return nil
+}
+
+func TestResumeDownloadVerified(t *testing.T) {
+	for _, osHashAlgo := range []string{"", "sha512"} {
+		th.SetupHTTP()
+		HandleGetImageWithChecksumSuccessfully(t, osHashAlgo)
+		HandleGetImageDataRangeSuccessfully(t)
+
+		f := &partialFile{data: []byte{34, 87, 0, 23, 23, 23}}
+		res, err := imagedata.ResumeDownloadVerified(fakeclient.ServiceClient(), "da3b75d9-3f4a-40e7-8a2c-bfab23927dea", f)
+		th.AssertNoErr(t, err)
+		th.AssertEquals(t, int64(6), res.ResumedFrom)
+		th.AssertEquals(t, true, res.RehashErr == nil)
+		th.AssertByteArrayEquals(t, []byte{34, 87, 0, 23, 23, 23, 56, 255, 254, 0}, f.data)
+		if osHashAlgo == "" {
+			th.AssertEquals(t, "md5", res.Algorithm)
+		} else {
+			th.AssertEquals(t, "sha512", res.Algorithm)
+		}
+
+		th.TeardownHTTP()
+	}
+}
+
+func TestResumeDownloadVerifiedMismatch(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	HandleGetImageWithChecksumSuccessfully(t, "sha512")
+	HandleGetImageDataRangeSuccessfully(t)
+
+	// The partial data is corrupt, which only the whole-image hash can tell.
+	f := &partialFile{data: []byte{34, 87, 0, 23, 23, 24}}
+	_, err := imagedata.ResumeDownloadVerified(fakeclient.ServiceClient(), "da3b75d9-3f4a-40e7-8a2c-bfab23927dea", f)
+	mismatch, ok := err.(images.ErrChecksumMismatch)
+	if !ok {
+		t.Fatalf("Expected an ErrChecksumMismatch, got %v", err)
+	}
+	th.AssertEquals(t, "sha512", mismatch.Algorithm)
+	th.AssertEquals(t, imageDataSHA512, mismatch.Expected)
+	th.AssertEquals(t, "f78b8f256560f89a34d41997e393352483b6e720e61b5a4020923609ad8790264da9b327a196d2c7cdfb586d205d0f5aadb9ce15e7e84ee2f69d7d719ca0915f", mismatch.Actual)
+}
+
+func TestResumeDownloadVerifiedRehashFails(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	HandleGetImageWithChecksumSuccessfully(t, "")
+	HandleGetImageDataSuccessfully(t)
+
+	f := &partialFile{data: []byte{34, 87, 0, 23, 23, 23}, failRead: true}
+	res, err := imagedata.ResumeDownloadVerified(fakeclient.ServiceClient(), "da3b75d9-3f4a-40e7-8a2c-bfab23927dea", f)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, int64(0), res.ResumedFrom)
+	th.AssertEquals(t, "read failed", res.RehashErr.Error())
+	th.AssertByteArrayEquals(t, []byte{34, 87, 0, 23, 23, 23, 56, 255, 254, 0}, f.data)
+}
@@ -0,0 +1,477 @@
+package imagedata
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/imageservice/v2/images"
+)
+
+// ExportManifestName is the name of the manifest file in an Export bundle.
+const ExportManifestName = "metadata.json"
+
+// ExportManifest describes the image bundled by Export. It is stored as JSON
+// in the bundle's ExportManifestName file, ahead of the image data.
+type ExportManifest struct {
+	ID              string                 `json:"id"`
+	Name            string                 `json:"name"`
+	ContainerFormat string                 `json:"container_format"`
+	DiskFormat      string                 `json:"disk_format"`
+	MinDisk         int                    `json:"min_disk"`
+	MinRAM          int                    `json:"min_ram"`
+	Tags            []string               `json:"tags"`
+	Properties      map[string]interface{} `json:"properties"`
+	Checksum        string                 `json:"checksum"`
+	Size            int64                  `json:"size"`
+
+	// DiskFile is the name of the bundle file holding the image data.
+	DiskFile string `json:"disk_file"`
+}
+
+// Export retrieves an image and its data and streams them as a tar bundle:
+// an ExportManifestName file describing the image, followed by the image data
+// in the file named by the manifest's DiskFile ("disk.<disk_format>"). The
+// bundle is composed on the client; the Image service has no export API.
+//
+// The image is only downloaded as the returned reader is consumed, and the
+// caller must close it. An error downloading the data, or data that does not
+// match the image's reported size, is returned from Read.
+func Export(client *gophercloud.ServiceClient, imageID string) (io.ReadCloser, error) {
+	image, err := images.Get(client, imageID).Extract()
+	if err != nil {
+		return nil, err
+	}
+
+	diskFile := "disk"
+	if image.DiskFormat != "" {
+		diskFile += "." + image.DiskFormat
+	}
+
+	manifest, err := json.MarshalIndent(ExportManifest{
+		ID:              image.ID,
+		Name:            image.Name,
+		ContainerFormat: image.ContainerFormat,
+		DiskFormat:      image.DiskFormat,
+		MinDisk:         image.MinDiskGigabytes,
+		MinRAM:          image.MinRAMMegabytes,
+		Tags:            image.Tags,
+		Properties:      image.Properties,
+		Checksum:        image.Checksum,
+		Size:            image.SizeBytes,
+		DiskFile:        diskFile,
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := Download(client, imageID).Extract()
+	if err != nil {
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		err := writeExport(pw, manifest, diskFile, image.SizeBytes, data)
+		if closer, ok := data.(io.Closer); ok {
+			closer.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	return pr, nil
+}
+
+// writeExport writes the tar bundle for Export to w.
+func writeExport(w io.Writer, manifest []byte, diskFile string, size int64, data io.Reader) error {
+	tw := tar.NewWriter(w)
+	now := time.Now()
+
+	err := tw.WriteHeader(&tar.Header{
+		Name:    ExportManifestName,
+		Mode:    0644,
+		Size:    int64(len(manifest)),
+		ModTime: now,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := tw.Write(manifest); err != nil {
+		return err
+	}
+
+	err = tw.WriteHeader(&tar.Header{
+		Name:    diskFile,
+		Mode:    0644,
+		Size:    size,
+		ModTime: now,
+	})
+	if err != nil {
+		return err
+	}
+	n, err := io.Copy(tw, data)
+	if err != nil {
+		return err
+	}
+	if n != size {
+		return fmt.Errorf("Image data is %d bytes, but the image size is %d bytes", n, size)
+	}
+
+	return tw.Close()
+}
+
+// CreateWithData creates an image and uploads its data in one step, and
+// returns the image once it is active. After the upload, the image is polled
+// for up to secs seconds while the Image service processes its data; secs
+// must be positive. If the image is not active in time, a
+// gophercloud.ErrTimeout is returned and the image is left as it is, to be
+// waited for with images.WaitForStatus.
+//
+// If the upload fails, the image record is deleted before the error is
+// returned, so that no queued image is left behind. If the image is killed or
+// deleted while its data is processed, it is deleted as well and an
+// images.ErrImageStatus is returned. Deleting it is best-effort: if the delete
+// fails too, the original error is still the one returned.
+func CreateWithData(client *gophercloud.ServiceClient, opts images.CreateOptsBuilder, data io.Reader, secs int) (*images.Image, error) {
+	if secs < 1 {
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "secs"
+		err.Value = secs
+		err.Info = "secs must be positive"
+		return nil, err
+	}
+
+	image, err := images.Create(client, opts).Extract()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := Upload(client, image.ID, data).ExtractErr(); err != nil {
+		images.Delete(client, image.ID)
+		return nil, err
+	}
+
+	id := image.ID
+	err = gophercloud.Poll(context.Background(), time.Second, time.Duration(secs)*time.Second, func() (bool, error) {
+		image, err = images.Get(client, id).Extract()
+		if err != nil {
+			return false, err
+		}
+
+		switch image.Status {
+		case images.ImageStatusActive:
+			return true, nil
+		case images.ImageStatusKilled, images.ImageStatusDeleted, images.ImageStatusPendingDelete:
+			return false, images.ErrImageStatus{ImageID: id, Status: image.Status}
+		}
+
+		return false, nil
+	})
+	if err != nil {
+		if _, ok := err.(images.ErrImageStatus); ok {
+			images.Delete(client, id)
+		}
+		return nil, err
+	}
+
+	return image, nil
+}
+
+// hashAlgorithms maps the os_hash_algo names CreateWithVerifiedData supports
+// to their implementations.
+var hashAlgorithms = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha384": sha512.New384,
+	"sha512": sha512.New,
+}
+
+// CreateWithVerifiedData is CreateWithData for data whose hash has been
+// computed in advance: it ensures that the image data hashes to hashValue
+// under hashAlgo, e.g. "sha512".
+//
+// The Image service computes os_hash_algo and os_hash_value itself and does
+// not accept them on create, so the data is verified here instead: it is
+// hashed as it is uploaded, and if the Image service hashes with the same
+// algorithm, its os_hash_value must match as well. On a mismatch, the image
+// is deleted and an images.ErrImageHashMismatch is returned. The image is
+// waited for, for up to secs seconds, and an image the Image service killed
+// while processing its data is reported as an images.ErrImageStatus, as by
+// CreateWithData.
+func CreateWithVerifiedData(client *gophercloud.ServiceClient, opts images.CreateOptsBuilder, data io.Reader, hashAlgo, hashValue string, secs int) (*images.Image, error) {
+	newHash, ok := hashAlgorithms[strings.ToLower(hashAlgo)]
+	if !ok {
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "hashAlgo"
+		err.Value = hashAlgo
+		err.Info = "unsupported hash algorithm"
+		return nil, err
+	}
+
+	h := newHash()
+	image, err := CreateWithData(client, opts, io.TeeReader(data, h), secs)
+	if err != nil {
+		return nil, err
+	}
+
+	actual := hex.EncodeToString(h.Sum(nil))
+	if strings.EqualFold(actual, hashValue) && image.OSHashValue != "" && strings.EqualFold(image.OSHashAlgo, hashAlgo) {
+		actual = image.OSHashValue
+	}
+	if !strings.EqualFold(actual, hashValue) {
+		images.Delete(client, image.ID)
+		return nil, images.ErrImageHashMismatch{
+			ImageID:   image.ID,
+			Algorithm: hashAlgo,
+			Expected:  hashValue,
+			Actual:    actual,
+		}
+	}
+
+	return image, nil
+}
+
+// PartialFile is a partially downloaded image file, such as an *os.File
+// opened for reading and writing.
+type PartialFile interface {
+	io.ReadWriteSeeker
+	Truncate(size int64) error
+}
+
+// ResumeResult describes how ResumeDownloadVerified completed a download.
+type ResumeResult struct {
+	// ResumedFrom is the offset the download was resumed from, or 0 if the
+	// whole image was downloaded.
+	ResumedFrom int64
+
+	// Algorithm is the algorithm the image data was verified with.
+	Algorithm string
+
+	// RehashErr is set if the data already in the partial file could not be
+	// hashed again, in which case the partial file was discarded and the whole
+	// image downloaded and verified instead. It is a warning, not a failure.
+	RehashErr error
+}
+
+// ResumeDownloadVerified completes the download of an image into f, which
+// holds the data downloaded so far, and verifies the checksum of the whole
+// image. The data already in f is read and hashed again, so that a resumed
+// download is verified just like a complete one, and only the rest of the
+// image is downloaded, with DownloadFrom.
+//
+// The data is verified against the image's OSHashValue if the Image service
+// supports multihash with an algorithm listed by CreateWithVerifiedData, and
+// against its MD5 Checksum otherwise. On a mismatch, an
+// images.ErrChecksumMismatch is returned, and f is left as it is.
+//
+// f is started over from the beginning if the data in it cannot be hashed
+// again, if it is longer than the image, or if the Image service ignores the
+// requested range. The first case is reported in the result's RehashErr.
+func ResumeDownloadVerified(client *gophercloud.ServiceClient, imageID string, f PartialFile) (*ResumeResult, error) {
+	image, err := images.Get(client, imageID).Extract()
+	if err != nil {
+		return nil, err
+	}
+
+	algorithm, expected := "md5", image.Checksum
+	if _, ok := hashAlgorithms[strings.ToLower(image.OSHashAlgo)]; ok && image.OSHashValue != "" {
+		algorithm, expected = strings.ToLower(image.OSHashAlgo), image.OSHashValue
+	}
+	if expected == "" {
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "imageID"
+		err.Value = imageID
+		err.Info = "the image has no checksum to verify its data against"
+		return nil, err
+	}
+	newHash := hashAlgorithms[algorithm]
+
+	offset, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ResumeResult{Algorithm: algorithm}
+	h := newHash()
+	if offset > 0 {
+		if image.SizeBytes > 0 && offset > image.SizeBytes {
+			offset = 0
+		} else if err := rehashPartial(f, h, offset); err != nil {
+			result.RehashErr = err
+			offset = 0
+		}
+	}
+
+	// Nothing is left to download if the partial file is already complete.
+	if offset == 0 || offset != image.SizeBytes {
+		var res DownloadResult
+		if offset > 0 {
+			res = DownloadFrom(client, imageID, offset)
+		} else {
+			res = Download(client, imageID)
+		}
+		data, err := res.Extract()
+		if err != nil {
+			return nil, err
+		}
+		if closer, ok := data.(io.Closer); ok {
+			defer closer.Close()
+		}
+
+		if offset > 0 && !res.RangeHonored() {
+			offset = 0
+		}
+		if offset == 0 {
+			h = newHash()
+			if err := f.Truncate(0); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			return nil, err
+		}
+		if _, err := io.Copy(io.MultiWriter(f, h), data); err != nil {
+			return nil, err
+		}
+	}
+
+	actual := hex.EncodeToString(h.Sum(nil))
+	if !strings.EqualFold(actual, expected) {
+		return nil, images.ErrChecksumMismatch{
+			ImageID:   imageID,
+			Algorithm: algorithm,
+			Expected:  expected,
+			Actual:    actual,
+		}
+	}
+
+	result.ResumedFrom = offset
+	return result, nil
+}
+
+// rehashPartial writes the first size bytes of f to h.
+func rehashPartial(f io.ReadSeeker, h hash.Hash, size int64) error {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	_, err := io.CopyN(h, f, size)
+	return err
+}
+
+// DownloadTeeOpts contains options for a DownloadTee call.
+type DownloadTeeOpts struct {
+	// StopOnError stops the download as soon as any destination fails.
+	// By default a failing destination is dropped and the others continue.
+	StopOnError bool
+}
+
+// downloadTeeChunkSize is the amount of image data DownloadTee reads before
+// writing it to the destinations.
+const downloadTeeChunkSize = 1 << 20
+
+// DownloadTee downloads an image's data once and writes it to every writer
+// concurrently, so that an image can be copied to several destinations
+// without downloading it several times. Each chunk of data is written to all
+// destinations before the next one is read, so the download proceeds at the
+// pace of the slowest destination.
+//
+// If a destination fails, it receives no further data. Unless
+// opts.StopOnError is set, the download continues for the other destinations
+// and stops only if all of them have failed. Destination failures are
+// reported as an ErrDownloadTee once the download ends; an error downloading
+// the data itself is returned as-is.
+func DownloadTee(client *gophercloud.ServiceClient, imageID string, opts DownloadTeeOpts, writers ...io.Writer) error {
+	data, err := Download(client, imageID).Extract()
+	if err != nil {
+		return err
+	}
+	if closer, ok := data.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	failed := make(map[int]error)
+	buf := make([]byte, downloadTeeChunkSize)
+	for len(failed) < len(writers) {
+		n, readErr := io.ReadFull(data, buf)
+		if n > 0 {
+			writeTeeChunk(buf[:n], writers, failed)
+			if opts.StopOnError && len(failed) > 0 {
+				break
+			}
+		}
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			break
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+
+	if len(failed) > 0 {
+		return ErrDownloadTee{ImageID: imageID, Errors: failed}
+	}
+	return nil
+}
+
+// writeTeeChunk writes chunk concurrently to each writer that has not failed
+// yet, recording new failures in failed.
+func writeTeeChunk(chunk []byte, writers []io.Writer, failed map[int]error) {
+	errs := make([]error, len(writers))
+	var wg sync.WaitGroup
+	for i, w := range writers {
+		if _, ok := failed[i]; ok {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, w io.Writer) {
+			defer wg.Done()
+			_, errs[i] = w.Write(chunk)
+		}(i, w)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			failed[i] = err
+		}
+	}
+}
+
+// ErrDownloadTee is the error when DownloadTee could not write the image data
+// to one or more destinations.
+type ErrDownloadTee struct {
+	gophercloud.BaseError
+	ImageID string
+
+	// Errors maps the index of each failed writer to its error.
+	Errors map[int]error
+}
+
+func (e ErrDownloadTee) Error() string {
+	indexes := make([]int, 0, len(e.Errors))
+	for i := range e.Errors {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+
+	msgs := make([]string, len(indexes))
+	for j, i := range indexes {
+		msgs[j] = fmt.Sprintf("writer %d: %s", i, e.Errors[i])
+	}
+	return fmt.Sprintf("Unable to write data of image [%s] to %d destination(s): %s",
+		e.ImageID, len(e.Errors), strings.Join(msgs, "; "))
+}
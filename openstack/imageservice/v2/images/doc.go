@@ -1,6 +1,7 @@
 /*
 Package images enables management and retrieval of images from the OpenStack
-Image Service.
+Image Service. Image data is uploaded and downloaded with the imagedata
+package.
 
 Example to List Images
 
@@ -34,6 +35,23 @@ Example to Create an Image
 		panic(err)
 	}
 
+Example to Check Image Properties Against the Image Schema
+
+	schema, err := images.GetImageSchema(imageClient).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	// Custom properties must be strings, so this fails with an
+	// ErrSchemaViolation for hw_cpu_cores.
+	err = schema.Validate(map[string]interface{}{
+		"hw_disk_bus":  "scsi",
+		"hw_cpu_cores": 4,
+	})
+	if err != nil {
+		panic(err)
+	}
+
 Example to Update an Image
 
 	imageID := "1bea47ed-f6a9-463b-b423-14b9cca9ad27"
@@ -49,6 +67,52 @@ Example to Update an Image
 		panic(err)
 	}
 
+Example to Import Image Data from a URL
+
+	methods, err := images.ListImportMethods(imageClient).Extract()
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("%+v\n", methods)
+
+	imageID := "1bea47ed-f6a9-463b-b423-14b9cca9ad27"
+	importOpts := images.ImportOpts{
+		Method: images.ImportMethodWebDownload,
+		URI:    "https://cloud-images.ubuntu.com/bionic/current/bionic-server-cloudimg-amd64.img",
+	}
+
+	err = images.Import(imageClient, imageID, importOpts).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Deactivate and Reactivate an Image
+
+	imageID := "1bea47ed-f6a9-463b-b423-14b9cca9ad27"
+	err := images.Deactivate(imageClient, imageID).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
+	err = images.Reactivate(imageClient, imageID).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Add and Remove a Tag
+
+	imageID := "1bea47ed-f6a9-463b-b423-14b9cca9ad27"
+
+	err := images.AddTag(imageClient, imageID, "channel-stable", nil).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
+	err = images.DeleteTag(imageClient, imageID, "channel-beta", nil).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
 Example to Delete an Image
 
 	imageID := "1bea47ed-f6a9-463b-b423-14b9cca9ad27"
@@ -56,5 +120,13 @@ Example to Delete an Image
 	if err != nil {
 		panic(err)
 	}
+
+Example to Delete an Image's Data from One Store
+
+	imageID := "1bea47ed-f6a9-463b-b423-14b9cca9ad27"
+	err := images.DeleteFromStore(imageClient, imageID, "fast").ExtractErr()
+	if err != nil {
+		panic(err)
+	}
 */
 package images
@@ -0,0 +1,163 @@
+package images
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+// ErrChecksumMismatch is the error when the checksum the Image service
+// reports for an image's data does not match a locally computed one.
+type ErrChecksumMismatch struct {
+	gophercloud.BaseError
+	ImageID string
+
+	// Algorithm is the algorithm the checksums were computed with.
+	Algorithm string
+
+	// Expected is the locally computed checksum.
+	Expected string
+
+	// Actual is the checksum reported by the Image service.
+	Actual string
+}
+
+func (e ErrChecksumMismatch) Error() string {
+	return fmt.Sprintf("Local %s checksum [%s] does not match checksum [%s] of image [%s]",
+		e.Algorithm, e.Expected, e.Actual, e.ImageID)
+}
+
+// ErrImageHashMismatch is the error when the data of a newly created image
+// does not hash to the expected value. The image has been deleted.
+type ErrImageHashMismatch struct {
+	gophercloud.BaseError
+	ImageID string
+
+	// Algorithm is the hash algorithm, as named by os_hash_algo.
+	Algorithm string
+
+	// Expected is the expected hexdigest.
+	Expected string
+
+	// Actual is the hexdigest of the data, as computed while it was uploaded
+	// or as reported by the Image service in os_hash_value.
+	Actual string
+}
+
+func (e ErrImageHashMismatch) Error() string {
+	return fmt.Sprintf("Expected %s hash [%s] does not match hash [%s] of image [%s]",
+		e.Algorithm, e.Expected, e.Actual, e.ImageID)
+}
+
+// ErrImageNameConflict is the error when an image is created with
+// CreateOpts.RequireUniqueName, but images with the same name exist.
+type ErrImageNameConflict struct {
+	gophercloud.BaseError
+	Name string
+
+	// IDs are the IDs of the existing images.
+	IDs []string
+}
+
+func (e ErrImageNameConflict) Error() string {
+	return fmt.Sprintf("Image name [%s] is already used by image(s) %s",
+		e.Name, strings.Join(e.IDs, ", "))
+}
+
+// ErrImageNotQueued is the error when UpdateFormats is asked to change the
+// formats of an image that is no longer queued.
+type ErrImageNotQueued struct {
+	gophercloud.BaseError
+	ImageID string
+	Status  ImageStatus
+}
+
+func (e ErrImageNotQueued) Error() string {
+	return fmt.Sprintf("Formats of image [%s] can only be changed while it is queued, but its status is [%s]",
+		e.ImageID, e.Status)
+}
+
+// ErrImageNoData is the error when the data of an image is downloaded before
+// any has been uploaded, typically because the image is still queued.
+type ErrImageNoData struct {
+	gophercloud.BaseError
+	ImageID string
+}
+
+func (e ErrImageNoData) Error() string {
+	return fmt.Sprintf("Image [%s] has no data", e.ImageID)
+}
+
+// ErrImageFormatChange is the error when the Image service refuses to change
+// an image's container or disk format. Status is the image's status as seen
+// before the update, and is empty if UpdateFormatsOpts.Force was set.
+type ErrImageFormatChange struct {
+	gophercloud.ErrUnexpectedResponseCode
+	ImageID string
+	Status  ImageStatus
+}
+
+func (e ErrImageFormatChange) Error() string {
+	return fmt.Sprintf("Image service refused to change the formats of image [%s] with status [%s]: %s",
+		e.ImageID, e.Status, e.ErrUnexpectedResponseCode.Error())
+}
+
+// ErrImageStatus is the error when an image enters a status it cannot recover
+// from while being waited on.
+type ErrImageStatus struct {
+	gophercloud.BaseError
+	ImageID string
+	Status  ImageStatus
+}
+
+func (e ErrImageStatus) Error() string {
+	return fmt.Sprintf("Image [%s] entered status [%s]", e.ImageID, e.Status)
+}
+
+// ErrPropertyNotFound is the error when an image does not have a requested
+// property.
+type ErrPropertyNotFound struct {
+	gophercloud.BaseError
+	Key string
+}
+
+func (e ErrPropertyNotFound) Error() string {
+	return fmt.Sprintf("Image has no property [%s]", e.Key)
+}
+
+// ErrPropertyType is the error when an image property cannot be converted to
+// the requested type.
+type ErrPropertyType struct {
+	gophercloud.BaseError
+	Key   string
+	Value interface{}
+	Type  PropertyType
+}
+
+func (e ErrPropertyType) Error() string {
+	return fmt.Sprintf("Image property [%s] with value [%v] is not of type [%s]", e.Key, e.Value, e.Type)
+}
+
+// ErrSchemaViolation is the error when Schema.Validate finds a property the
+// schema does not accept.
+type ErrSchemaViolation struct {
+	gophercloud.BaseError
+
+	// Property is the name of the property, e.g. "min_ram", "tags[2]" or
+	// "outer.inner" for a property of an object.
+	Property string
+
+	// Value is the value of the property, if it has one.
+	Value interface{}
+
+	// Reason says what is wrong with the property, e.g. "is read-only".
+	Reason string
+}
+
+func (e ErrSchemaViolation) Error() string {
+	if e.Value == nil {
+		return fmt.Sprintf("Image property [%s] %s", e.Property, e.Reason)
+	}
+	return fmt.Sprintf("Image property [%s] with value [%v] %s", e.Property, e.Value, e.Reason)
+}
@@ -2,7 +2,10 @@ package images
 
 import (
 	"fmt"
+	"net/http"
 	"net/url"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gophercloud/gophercloud"
@@ -52,10 +55,17 @@ type ListOpts struct {
 	// such as "in:saving,queued".
 	Status ImageStatus `q:"status"`
 
-	// SizeMin filters on the size_min image property.
+	// Statuses filters on images in any of the given statuses. It is sent as
+	// a single "in:" status filter, which requires Image API v2.5 or later;
+	// see ListWithStatuses for a fallback for older clouds. It cannot be
+	// combined with Status.
+	Statuses []ImageStatus
+
+	// SizeMin filters on images of at least this size, in bytes.
 	SizeMin int64 `q:"size_min"`
 
-	// SizeMax filters on the size_max image property.
+	// SizeMax filters on images of at most this size, in bytes. If both are
+	// set, SizeMin must not be greater than SizeMax.
 	SizeMax int64 `q:"size_max"`
 
 	// Sort sorts the results using the new style of sorting. See the OpenStack
@@ -64,6 +74,11 @@ type ListOpts struct {
 	// Sort cannot be used with the classic sort options (sort_key and sort_dir).
 	Sort string `q:"sort"`
 
+	// Sorts sorts the results on each of the keys in turn, for example on
+	// name ascending and then on created_at descending. It is sent as a
+	// new-style Sort, so it cannot be combined with Sort, SortKey or SortDir.
+	Sorts []ImageSort
+
 	// SortKey will sort the results based on a specified image property.
 	SortKey string `q:"sort_key"`
 
@@ -88,13 +103,90 @@ type ListOpts struct {
 	// Multiple disk formats can be specified by constructing a string
 	// such as "in:qcow2,iso".
 	DiskFormat string `q:"disk_format"`
+
+	// MarkerFallback enables synthesizing the next page from the ID of the
+	// last image when the server omits the "next" link but returned a full
+	// page (as many images as Limit). It has no effect unless Limit is set.
+	//
+	// This is opt-in because a server that correctly omits "next" on a final
+	// page which happens to be full will cost one extra, empty request.
+	MarkerFallback bool
+
+	// AdaptiveLimit, if set, tunes the page size from one page to the next,
+	// starting from Limit and growing toward the server's effective maximum.
+	// Its PageSize method reports the negotiated size. It has no effect
+	// unless Limit is set.
+	AdaptiveLimit *pagination.AdaptiveLimit
+
+	// NormalizeTags, if set, is applied to each of Tags before the query is
+	// built. Tags are sent byte-for-byte as given otherwise.
+	NormalizeTags TagNormalizer
 }
 
 // ToImageListQuery formats a ListOpts into a query string.
 func (opts ListOpts) ToImageListQuery() (string, error) {
+	if opts.SizeMin > 0 && opts.SizeMax > 0 && opts.SizeMin > opts.SizeMax {
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "images.ListOpts.SizeMin"
+		err.Value = opts.SizeMin
+		err.Info = "SizeMin must not be greater than SizeMax"
+		return "", err
+	}
+
+	if opts.Status != "" && len(opts.Statuses) > 0 {
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "images.ListOpts.Statuses"
+		err.Value = opts.Statuses
+		err.Info = "Status and Statuses cannot be used together"
+		return "", err
+	}
+
+	var sorts []string
+	if len(opts.Sorts) > 0 {
+		if opts.Sort != "" || opts.SortKey != "" || opts.SortDir != "" {
+			err := gophercloud.ErrInvalidInput{}
+			err.Argument = "images.ListOpts.Sorts"
+			err.Value = opts.Sorts
+			err.Info = "Sorts cannot be used together with Sort, SortKey or SortDir"
+			return "", err
+		}
+		for _, s := range opts.Sorts {
+			if s.Key == "" {
+				err := gophercloud.ErrMissingInput{}
+				err.Argument = "images.ListOpts.Sorts.Key"
+				return "", err
+			}
+			switch s.Dir {
+			case "":
+				sorts = append(sorts, s.Key)
+			case SortAsc, SortDesc:
+				sorts = append(sorts, s.Key+":"+string(s.Dir))
+			default:
+				err := gophercloud.ErrInvalidInput{}
+				err.Argument = "images.ListOpts.Sorts.Dir"
+				err.Value = s.Dir
+				err.Info = "Dir must be asc or desc"
+				return "", err
+			}
+		}
+	}
+
+	opts.Tags = normalizeTags(opts.Tags, opts.NormalizeTags)
 	q, err := gophercloud.BuildQueryString(opts)
 	params := q.Query()
 
+	if len(opts.Statuses) > 0 {
+		statuses := make([]string, len(opts.Statuses))
+		for i, status := range opts.Statuses {
+			statuses[i] = string(status)
+		}
+		params.Set("status", "in:"+strings.Join(statuses, ","))
+	}
+
+	if len(sorts) > 0 {
+		params.Set("sort", strings.Join(sorts, ","))
+	}
+
 	if opts.CreatedAtQuery != nil {
 		createdAt := opts.CreatedAtQuery.Date.Format(time.RFC3339)
 		if v := opts.CreatedAtQuery.Filter; v != "" {
@@ -121,18 +213,90 @@ func (opts ListOpts) ToImageListQuery() (string, error) {
 // List implements image list request.
 func List(c *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pager {
 	url := listURL(c)
+	var markerFallback bool
+	var adaptiveLimit *pagination.AdaptiveLimit
 	if opts != nil {
 		query, err := opts.ToImageListQuery()
 		if err != nil {
 			return pagination.Pager{Err: err}
 		}
 		url += query
+
+		switch o := opts.(type) {
+		case ListOpts:
+			markerFallback = o.MarkerFallback
+			adaptiveLimit = o.AdaptiveLimit
+		case *ListOpts:
+			markerFallback = o.MarkerFallback
+			adaptiveLimit = o.AdaptiveLimit
+		}
 	}
 	return pagination.NewPager(c, url, func(r pagination.PageResult) pagination.Page {
-		return ImagePage{pagination.LinkedPageBase{PageResult: r}}
+		return ImagePage{
+			LinkedPageBase: pagination.LinkedPageBase{PageResult: r},
+			markerFallback: markerFallback,
+			adaptiveLimit:  adaptiveLimit,
+		}
 	})
 }
 
+// AllImages lists images like List, but yields them one at a time on the
+// returned channel, fetching further pages as the channel is drained. If
+// retrieving or extracting a page fails, the error is sent as the last value
+// on the channel. The channel is closed once all images have been sent or an
+// error has occurred.
+//
+// The returned stop function ends the iteration early; it must be called if
+// the channel is not drained, so that the goroutine fetching pages can exit.
+// It waits for that goroutine and returns the error that ended the
+// iteration, if any. Calling it more than once is safe.
+func AllImages(c *gophercloud.ServiceClient, opts ListOptsBuilder) (<-chan ImageOrError, func() error) {
+	ch := make(chan ImageOrError)
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	var stopOnce sync.Once
+	var iterErr error
+
+	go func() {
+		defer close(finished)
+		defer close(ch)
+
+		send := func(v ImageOrError) bool {
+			select {
+			case ch <- v:
+				return true
+			case <-done:
+				return false
+			}
+		}
+
+		err := List(c, opts).EachPage(func(page pagination.Page) (bool, error) {
+			images, err := ExtractImages(page)
+			if err != nil {
+				return false, err
+			}
+			for _, image := range images {
+				if !send(ImageOrError{Image: image}) {
+					return false, nil
+				}
+			}
+			return true, nil
+		})
+		if err != nil {
+			iterErr = err
+			send(ImageOrError{Err: err})
+		}
+	}()
+
+	stop := func() error {
+		stopOnce.Do(func() { close(done) })
+		<-finished
+		return iterErr
+	}
+
+	return ch, stop
+}
+
 // CreateOptsBuilder allows extensions to add parameters to the Create request.
 type CreateOptsBuilder interface {
 	// Returns value that can be passed to json.Marshal
@@ -176,11 +340,56 @@ type CreateOpts struct {
 	// properties is a set of properties, if any, that
 	// are associated with the image.
 	Properties map[string]string `json:"-"`
+
+	// NormalizeTags, if set, is applied to each of Tags before the image is
+	// created. Tags are sent byte-for-byte as given otherwise.
+	NormalizeTags TagNormalizer `json:"-"`
+
+	// RequireUniqueName makes Create fail with an ErrImageNameConflict if an
+	// image with the same name already exists, instead of creating another
+	// image with that name. Only images visible to the caller are checked, and
+	// the check is not atomic: an image created by someone else between the
+	// check and the create still results in a duplicate.
+	RequireUniqueName bool `json:"-"`
+
+	// RequestID, if set, is sent as the request's X-OpenStack-Request-ID, so
+	// that a retried Create can be told apart from a new one by a deployment
+	// that deduplicates requests. It must be of the form returned by
+	// gophercloud.NewRequestID. See Create for which clouds honor it.
+	RequestID string `json:"-"`
 }
 
+// maxImageMinimum is the largest min_disk or min_ram value Glance can store.
+const maxImageMinimum = 1<<31 - 1
+
 // ToImageCreateMap assembles a request body based on the contents of
 // a CreateOpts.
 func (opts CreateOpts) ToImageCreateMap() (map[string]interface{}, error) {
+	if opts.MinDisk < 0 || opts.MinDisk > maxImageMinimum {
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "images.CreateOpts.MinDisk"
+		err.Value = opts.MinDisk
+		err.Info = fmt.Sprintf("MinDisk must be between 0 and %d", maxImageMinimum)
+		return nil, err
+	}
+
+	if opts.MinRAM < 0 || opts.MinRAM > maxImageMinimum {
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "images.CreateOpts.MinRAM"
+		err.Value = opts.MinRAM
+		err.Info = fmt.Sprintf("MinRAM must be between 0 and %d", maxImageMinimum)
+		return nil, err
+	}
+
+	if opts.RequestID != "" && !gophercloud.IsRequestID(opts.RequestID) {
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "images.CreateOpts.RequestID"
+		err.Value = opts.RequestID
+		err.Info = "RequestID must be of the form req-<uuid>"
+		return nil, err
+	}
+
+	opts.Tags = normalizeTags(opts.Tags, opts.NormalizeTags)
 	b, err := gophercloud.BuildRequestBody(opts, "")
 	if err != nil {
 		return nil, err
@@ -195,13 +404,206 @@ func (opts CreateOpts) ToImageCreateMap() (map[string]interface{}, error) {
 }
 
 // Create implements create image request.
+//
+// If CreateOpts.RequestID is set, it is sent as the X-OpenStack-Request-ID
+// header. The Image service itself does not deduplicate requests by it: it
+// only records it as the global request ID in its logs. Only a deployment
+// that puts a deduplicating proxy in front of the service honors it;
+// elsewhere it is a no-op. To make a retried Create safe on any cloud, set
+// CreateOpts.ID as well: the Image service rejects a second image with the
+// same ID with a 409 Conflict, after which the image can be retrieved by ID.
 func Create(client *gophercloud.ServiceClient, opts CreateOptsBuilder) (r CreateResult) {
 	b, err := opts.ToImageCreateMap()
 	if err != nil {
 		r.Err = err
 		return r
 	}
-	_, r.Err = client.Post(createURL(client), b, &r.Body, &gophercloud.RequestOpts{OkCodes: []int{201}})
+
+	var name, requestID string
+	switch o := opts.(type) {
+	case CreateOpts:
+		if o.RequireUniqueName {
+			name = o.Name
+		}
+		requestID = o.RequestID
+	case *CreateOpts:
+		if o.RequireUniqueName {
+			name = o.Name
+		}
+		requestID = o.RequestID
+	}
+	if name != "" {
+		ids, err := imageIDsByName(client, name)
+		if err != nil {
+			r.Err = err
+			return r
+		}
+		if len(ids) > 0 {
+			r.Err = ErrImageNameConflict{Name: name, IDs: ids}
+			return r
+		}
+	}
+	reqOpts := &gophercloud.RequestOpts{
+		OkCodes: []int{201, 204},
+	}
+	if requestID != "" {
+		reqOpts.MoreHeaders = map[string]string{gophercloud.RequestIDHeader: requestID}
+	}
+	r.Result = client.RequestResult("POST", createURL(client), b, reqOpts)
+	return
+}
+
+// imageIDsByName returns the IDs of the images named name.
+func imageIDsByName(client *gophercloud.ServiceClient, name string) ([]string, error) {
+	var ids []string
+	err := List(client, ListOpts{Name: name}).EachPage(func(page pagination.Page) (bool, error) {
+		images, err := ExtractImages(page)
+		if err != nil {
+			return false, err
+		}
+		for _, image := range images {
+			// A name such as "in:a,b" is read as a list by the filter.
+			if image.Name == name {
+				ids = append(ids, image.ID)
+			}
+		}
+		return true, nil
+	})
+	return ids, err
+}
+
+// Deactivate deactivates the active image with the provided ID. Its data can
+// no longer be downloaded or booted from by anyone but administrators, while
+// its metadata and members are kept. Deactivating a deactivated image is not
+// an error.
+func Deactivate(client *gophercloud.ServiceClient, id string) (r DeactivateResult) {
+	_, r.Err = client.Post(actionURL(client, id, "deactivate"), nil, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{204},
+	})
+	return
+}
+
+// Reactivate makes the deactivated image with the provided ID active again.
+// Reactivating an active image is not an error.
+func Reactivate(client *gophercloud.ServiceClient, id string) (r ReactivateResult) {
+	_, r.Err = client.Post(actionURL(client, id, "reactivate"), nil, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{204},
+	})
+	return
+}
+
+// ListStores lists the backing stores of an Image service with multiple
+// stores enabled. Image services without multiple stores respond with a 404.
+func ListStores(client *gophercloud.ServiceClient) (r ListStoresResult) {
+	_, r.Err = client.Get(storesURL(client), &r.Body, nil)
+	return
+}
+
+// DeleteFromStore deletes the data of the image with the provided ID from one
+// of the backing stores of an Image service with multiple stores enabled. The
+// image and its data in other stores are kept; Image.Stores no longer lists
+// the store afterwards. It requires Image API v2.10 or later.
+//
+// The Image service refuses, with a 409, to delete the image's data from its
+// only store, and answers 404 if the image has no data in the store.
+func DeleteFromStore(client *gophercloud.ServiceClient, id, store string) (r DeleteFromStoreResult) {
+	_, r.Err = client.Delete(storeImageURL(client, store, id), &gophercloud.RequestOpts{
+		OkCodes: []int{204},
+	})
+	return
+}
+
+// GetImageSchema retrieves the JSON schema of an image, which describes the
+// properties an image may have. Call Validate on the extracted Schema to check
+// the properties of an image before creating it.
+func GetImageSchema(client *gophercloud.ServiceClient) (r GetSchemaResult) {
+	_, r.Err = client.Get(schemaURL(client, "image"), &r.Body, nil)
+	return
+}
+
+// GetImagesSchema retrieves the JSON schema of a list of images. The schema of
+// each image is the Items of its "images" property.
+func GetImagesSchema(client *gophercloud.ServiceClient) (r GetSchemaResult) {
+	_, r.Err = client.Get(schemaURL(client, "images"), &r.Body, nil)
+	return
+}
+
+// ListImportMethods lists the import methods the Image service accepts in
+// ImportOpts.Method. Image services that predate interoperable image import
+// respond with a 404.
+func ListImportMethods(client *gophercloud.ServiceClient) (r ListImportMethodsResult) {
+	_, r.Err = client.Get(importInfoURL(client), &r.Body, nil)
+	return
+}
+
+// ImportOptsBuilder allows extensions to add additional parameters to the
+// Import request.
+type ImportOptsBuilder interface {
+	ToImageImportMap() (map[string]interface{}, error)
+}
+
+// ImportOpts represents options to import the data of an image.
+type ImportOpts struct {
+	// Method is the import method, one of those listed by ListImportMethods.
+	Method ImportMethod `json:"name" required:"true"`
+
+	// URI is the URL the data is downloaded from. It is required by
+	// ImportMethodWebDownload.
+	URI string `json:"uri,omitempty"`
+}
+
+// ToImageImportMap assembles a request body based on the contents of an
+// ImportOpts.
+func (opts ImportOpts) ToImageImportMap() (map[string]interface{}, error) {
+	if opts.Method == ImportMethodWebDownload && opts.URI == "" {
+		err := gophercloud.ErrMissingInput{}
+		err.Argument = "images.ImportOpts.URI"
+		return nil, err
+	}
+	return gophercloud.BuildRequestBody(opts, "method")
+}
+
+// Import starts importing the data of a queued image with the provided ID.
+// The import runs asynchronously: the image becomes active once it is done,
+// and killed if it fails. With ImportMethodGlanceDirect, the data must have
+// been uploaded with imagedata.Stage first, and the image is "uploading" until
+// then.
+func Import(client *gophercloud.ServiceClient, id string, opts ImportOptsBuilder) (r ImportResult) {
+	b, err := opts.ToImageImportMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = client.Post(importURL(client, id), b, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{202},
+	})
+	return
+}
+
+// AddTag adds a single tag to the image with the provided ID, leaving its
+// other tags untouched. Adding a tag the image already has is not an error.
+// If normalize is set, it is applied to tag before it is sent.
+func AddTag(client *gophercloud.ServiceClient, id, tag string, normalize TagNormalizer) (r AddTagResult) {
+	if normalize != nil {
+		tag = normalize(tag)
+	}
+	_, r.Err = client.Put(tagURL(client, id, tag), nil, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{204},
+	})
+	return
+}
+
+// DeleteTag removes a single tag from the image with the provided ID,
+// leaving its other tags untouched. If normalize is set, it is applied to tag
+// before it is sent; the Image service only removes a tag stored in exactly
+// that form.
+func DeleteTag(client *gophercloud.ServiceClient, id, tag string, normalize TagNormalizer) (r DeleteTagResult) {
+	if normalize != nil {
+		tag = normalize(tag)
+	}
+	_, r.Err = client.Delete(tagURL(client, id, tag), &gophercloud.RequestOpts{
+		OkCodes: []int{204},
+	})
 	return
 }
 
@@ -231,6 +633,69 @@ func Update(client *gophercloud.ServiceClient, id string, opts UpdateOptsBuilder
 	return
 }
 
+// SetProtected sets whether the image with the provided ID is protected from
+// deletion. A protected image cannot be deleted until it is unprotected.
+func SetProtected(client *gophercloud.ServiceClient, id string, protected bool) (r UpdateResult) {
+	return Update(client, id, UpdateOpts{ReplaceImageProtected{NewProtected: protected}})
+}
+
+// UpdateFormatsOpts contains the formats to set with UpdateFormats. Formats
+// left empty are not changed.
+type UpdateFormatsOpts struct {
+	ContainerFormat string
+	DiskFormat      string
+
+	// Force skips the check that the image is still queued.
+	Force bool
+}
+
+// UpdateFormats changes the container and/or disk format of the image with the
+// provided ID. The Image service only allows this while the image is queued,
+// so unless opts.Force is set the image is retrieved first and an
+// ErrImageNotQueued is returned without sending the update if it has any
+// other status. If the Image service itself rejects the change with a 403 or
+// 409, an ErrImageFormatChange is returned.
+func UpdateFormats(client *gophercloud.ServiceClient, id string, opts UpdateFormatsOpts) (r UpdateResult) {
+	var patches UpdateOpts
+	if opts.ContainerFormat != "" {
+		patches = append(patches, ReplaceImageContainerFormat{NewContainerFormat: opts.ContainerFormat})
+	}
+	if opts.DiskFormat != "" {
+		patches = append(patches, ReplaceImageDiskFormat{NewDiskFormat: opts.DiskFormat})
+	}
+	if len(patches) == 0 {
+		err := gophercloud.ErrMissingInput{}
+		err.Argument = "images.UpdateFormatsOpts.ContainerFormat/images.UpdateFormatsOpts.DiskFormat"
+		r.Err = err
+		return
+	}
+
+	var status ImageStatus
+	if !opts.Force {
+		image, err := Get(client, id).Extract()
+		if err != nil {
+			r.Err = err
+			return
+		}
+		status = image.Status
+		if status != ImageStatusQueued {
+			r.Err = ErrImageNotQueued{ImageID: id, Status: status}
+			return
+		}
+	}
+
+	r = Update(client, id, patches)
+	switch err := r.Err.(type) {
+	case gophercloud.ErrDefault403:
+		r.Err = ErrImageFormatChange{ErrUnexpectedResponseCode: err.ErrUnexpectedResponseCode, ImageID: id, Status: status}
+	case gophercloud.ErrUnexpectedResponseCode:
+		if err.Actual == http.StatusConflict {
+			r.Err = ErrImageFormatChange{ErrUnexpectedResponseCode: err, ImageID: id, Status: status}
+		}
+	}
+	return
+}
+
 // UpdateOptsBuilder allows extensions to add additional parameters to the
 // Update request.
 type UpdateOptsBuilder interface {
@@ -302,6 +767,35 @@ func (rc ReplaceImageChecksum) ToImagePatchMap() map[string]interface{} {
 	}
 }
 
+// ReplaceImageProtected represents an updated protected property request.
+type ReplaceImageProtected struct {
+	NewProtected bool
+}
+
+// ToImagePatchMap assembles a request body based on ReplaceImageProtected.
+func (r ReplaceImageProtected) ToImagePatchMap() map[string]interface{} {
+	return map[string]interface{}{
+		"op":    "replace",
+		"path":  "/protected",
+		"value": r.NewProtected,
+	}
+}
+
+// ReplaceImageHidden represents an updated os_hidden property request. A
+// hidden image is left out of image lists unless they ask for it.
+type ReplaceImageHidden struct {
+	NewHidden bool
+}
+
+// ToImagePatchMap assembles a request body based on ReplaceImageHidden.
+func (r ReplaceImageHidden) ToImagePatchMap() map[string]interface{} {
+	return map[string]interface{}{
+		"op":    "replace",
+		"path":  "/os_hidden",
+		"value": r.NewHidden,
+	}
+}
+
 // ReplaceImageTags represents an updated tags property request.
 type ReplaceImageTags struct {
 	NewTags []string
@@ -315,3 +809,74 @@ func (r ReplaceImageTags) ToImagePatchMap() map[string]interface{} {
 		"value": r.NewTags,
 	}
 }
+
+// ReplaceImageContainerFormat represents an updated container_format property
+// request. The Image service only accepts it while the image is queued.
+type ReplaceImageContainerFormat struct {
+	NewContainerFormat string
+}
+
+// ToImagePatchMap assembles a request body based on
+// ReplaceImageContainerFormat.
+func (r ReplaceImageContainerFormat) ToImagePatchMap() map[string]interface{} {
+	return map[string]interface{}{
+		"op":    "replace",
+		"path":  "/container_format",
+		"value": r.NewContainerFormat,
+	}
+}
+
+// ReplaceImageDiskFormat represents an updated disk_format property request.
+// The Image service only accepts it while the image is queued.
+type ReplaceImageDiskFormat struct {
+	NewDiskFormat string
+}
+
+// ToImagePatchMap assembles a request body based on ReplaceImageDiskFormat.
+func (r ReplaceImageDiskFormat) ToImagePatchMap() map[string]interface{} {
+	return map[string]interface{}{
+		"op":    "replace",
+		"path":  "/disk_format",
+		"value": r.NewDiskFormat,
+	}
+}
+
+// UpdateOp represents a valid update operation.
+type UpdateOp string
+
+const (
+	AddOp     UpdateOp = "add"
+	ReplaceOp UpdateOp = "replace"
+	RemoveOp  UpdateOp = "remove"
+)
+
+// UpdateImageProperty represents an update of a custom image property. Value
+// is ignored by RemoveOp.
+type UpdateImageProperty struct {
+	Op    UpdateOp
+	Name  string
+	Value string
+}
+
+// ToImagePatchMap assembles a request body based on UpdateImageProperty.
+func (r UpdateImageProperty) ToImagePatchMap() map[string]interface{} {
+	updateMap := map[string]interface{}{
+		"op":   r.Op,
+		"path": "/" + strings.Replace(strings.Replace(r.Name, "~", "~0", -1), "/", "~1", -1),
+	}
+	if r.Op != RemoveOp {
+		updateMap["value"] = r.Value
+	}
+	return updateMap
+}
+
+// RemoveImageProperty represents the removal of a custom image property. The
+// property is removed from the image, rather than set to an empty value.
+type RemoveImageProperty struct {
+	Name string
+}
+
+// ToImagePatchMap assembles a request body based on RemoveImageProperty.
+func (r RemoveImageProperty) ToImagePatchMap() map[string]interface{} {
+	return UpdateImageProperty{Op: RemoveOp, Name: r.Name}.ToImagePatchMap()
+}
@@ -0,0 +1,224 @@
+package images
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"unicode/utf8"
+)
+
+// Schema is a JSON schema published by the Image service, such as the schema
+// of an image returned by GetImageSchema. Only the parts of JSON schema the
+// Image service uses to describe images are kept.
+type Schema struct {
+	// Name is the name of the schema, e.g. "image". It is only set on the
+	// top-level schema.
+	Name string `json:"name"`
+
+	// Description describes the property the schema applies to.
+	Description string `json:"description"`
+
+	// Type lists the JSON types a value may have, e.g. "string" or "null". A
+	// value of any type is valid if it is empty.
+	Type []string `json:"-"`
+
+	// Properties are the schemas of the properties of an object.
+	Properties map[string]*Schema `json:"properties"`
+
+	// Required lists the properties an object must have.
+	Required []string `json:"required"`
+
+	// AdditionalProperties is the schema of the properties of an object that
+	// are not in Properties. Any value is valid for them if it is nil, unless
+	// NoAdditionalProperties is set.
+	AdditionalProperties *Schema `json:"-"`
+
+	// NoAdditionalProperties is set if an object may not have properties
+	// other than those in Properties.
+	NoAdditionalProperties bool `json:"-"`
+
+	// Items is the schema of the items of an array.
+	Items *Schema `json:"items"`
+
+	// Enum lists the values a value may have, if it is not empty.
+	Enum []interface{} `json:"enum"`
+
+	// ReadOnly is set for properties that are set by the Image service only.
+	ReadOnly bool `json:"readOnly"`
+
+	// MaxLength is the maximum number of characters of a string.
+	MaxLength *int `json:"maxLength"`
+
+	// Minimum and Maximum bound the value of a number.
+	Minimum *float64 `json:"minimum"`
+	Maximum *float64 `json:"maximum"`
+}
+
+// UnmarshalJSON reads a Schema, whose type may be a single type or a list of
+// types, and whose additional properties may be a schema or a bool.
+func (r *Schema) UnmarshalJSON(b []byte) error {
+	type tmp Schema
+	var s struct {
+		tmp
+		Type                 json.RawMessage `json:"type"`
+		AdditionalProperties json.RawMessage `json:"additionalProperties"`
+	}
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	*r = Schema(s.tmp)
+
+	if len(s.Type) > 0 {
+		var t string
+		if err := json.Unmarshal(s.Type, &t); err == nil {
+			r.Type = []string{t}
+		} else if err := json.Unmarshal(s.Type, &r.Type); err != nil {
+			return err
+		}
+	}
+
+	if len(s.AdditionalProperties) > 0 {
+		var allowed bool
+		if err := json.Unmarshal(s.AdditionalProperties, &allowed); err == nil {
+			r.NoAdditionalProperties = !allowed
+		} else if err := json.Unmarshal(s.AdditionalProperties, &r.AdditionalProperties); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate checks props, such as the properties of an image about to be
+// created, against the schema, so that mistakes are caught before the Image
+// service rejects the request. It returns an ErrSchemaViolation for the
+// first property, in alphabetical order, that the schema does not accept:
+// one that is read-only, not allowed, or whose value has the wrong type or
+// is out of range. A missing required property is reported first.
+func (r Schema) Validate(props map[string]interface{}) error {
+	return r.validateObject("", props)
+}
+
+func (r *Schema) validateObject(path string, props map[string]interface{}) error {
+	for _, name := range r.Required {
+		if _, ok := props[name]; !ok {
+			return ErrSchemaViolation{Property: path + name, Reason: "is required"}
+		}
+	}
+
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		prop, ok := r.Properties[name]
+		if !ok {
+			if r.NoAdditionalProperties {
+				return ErrSchemaViolation{Property: path + name, Reason: "is not allowed"}
+			}
+			prop = r.AdditionalProperties
+		}
+		if prop == nil {
+			continue
+		}
+		if prop.ReadOnly {
+			return ErrSchemaViolation{Property: path + name, Reason: "is read-only"}
+		}
+		if err := prop.validateValue(path+name, props[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Schema) validateValue(path string, value interface{}) error {
+	// Round-trip the value through JSON, so that it is checked as it would be
+	// sent, e.g. an ImageVisibility as a string and an int as a number.
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if len(r.Type) > 0 && !r.hasType(v) {
+		return ErrSchemaViolation{Property: path, Value: value, Reason: fmt.Sprintf("must be of type %v", r.Type)}
+	}
+
+	if len(r.Enum) > 0 {
+		var ok bool
+		for _, e := range r.Enum {
+			if reflect.DeepEqual(e, v) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return ErrSchemaViolation{Property: path, Value: value, Reason: fmt.Sprintf("must be one of %v", r.Enum)}
+		}
+	}
+
+	switch v := v.(type) {
+	case string:
+		if r.MaxLength != nil && utf8.RuneCountInString(v) > *r.MaxLength {
+			return ErrSchemaViolation{Property: path, Value: value, Reason: fmt.Sprintf("must be at most %d characters long", *r.MaxLength)}
+		}
+	case float64:
+		if r.Minimum != nil && v < *r.Minimum {
+			return ErrSchemaViolation{Property: path, Value: value, Reason: fmt.Sprintf("must be at least %v", *r.Minimum)}
+		}
+		if r.Maximum != nil && v > *r.Maximum {
+			return ErrSchemaViolation{Property: path, Value: value, Reason: fmt.Sprintf("must be at most %v", *r.Maximum)}
+		}
+	case []interface{}:
+		if r.Items != nil {
+			for i, item := range v {
+				if err := r.Items.validateValue(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+					return err
+				}
+			}
+		}
+	case map[string]interface{}:
+		return r.validateObject(path+".", v)
+	}
+	return nil
+}
+
+// hasType reports whether the JSON value v has one of the schema's types.
+func (r *Schema) hasType(v interface{}) bool {
+	for _, t := range r.Type {
+		switch v := v.(type) {
+		case nil:
+			if t == "null" {
+				return true
+			}
+		case bool:
+			if t == "boolean" {
+				return true
+			}
+		case string:
+			if t == "string" {
+				return true
+			}
+		case float64:
+			if t == "number" || t == "integer" && v == math.Trunc(v) {
+				return true
+			}
+		case []interface{}:
+			if t == "array" {
+				return true
+			}
+		case map[string]interface{}:
+			if t == "object" {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -1,10 +1,12 @@
 package testing
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
 
 	th "github.com/gophercloud/gophercloud/testhelper"
@@ -149,7 +151,7 @@ func HandleImageListSuccessfully(t *testing.T) {
 			}
 		}
 		t.Logf("Writing out %v image(s)", len(imageJSON))
-		fmt.Fprintf(w, strings.Join(imageJSON, ","))
+		fmt.Fprint(w, strings.Join(imageJSON, ","))
 
 		fmt.Fprintf(w, `],
 			    "next": "/images?marker=%s&limit=%v",
@@ -204,6 +206,33 @@ func HandleImageCreationSuccessfully(t *testing.T) {
 	})
 }
 
+// HandleImageCreationEmptyBody test setup
+// Some deployments return no body at all, only the image's Location.
+func HandleImageCreationEmptyBody(t *testing.T) {
+	th.Mux.HandleFunc("/images", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+		th.TestJSONRequest(t, r, `{"name": "Ubuntu 12.10"}`)
+
+		w.Header().Add("Location", th.Endpoint()+"v2/images/e7db3b45-8db7-47ad-8109-3fb55c2c24fd")
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// HandleImageCreationWithRequestID test setup
+func HandleImageCreationWithRequestID(t *testing.T, requestID string) {
+	th.Mux.HandleFunc("/images", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+		th.TestHeader(t, r, "X-OpenStack-Request-ID", requestID)
+		th.TestJSONRequest(t, r, `{"name": "Ubuntu 12.10"}`)
+
+		w.Header().Add("X-OpenStack-Request-ID", requestID)
+		w.Header().Add("Location", th.Endpoint()+"v2/images/e7db3b45-8db7-47ad-8109-3fb55c2c24fd")
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
 // HandleImageCreationSuccessfullyNulls test setup
 // JSON null values could be also returned according to behaviour https://bugs.launchpad.net/glance/+bug/1481512
 func HandleImageCreationSuccessfullyNulls(t *testing.T) {
@@ -283,6 +312,42 @@ func HandleImageGetSuccessfully(t *testing.T) {
 	})
 }
 
+// HandleImageGetSharedSuccessfully test setup
+func HandleImageGetSharedSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/images/07aa21a9-fa1a-430e-9a33-185be5982431", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{
+			"status": "active",
+			"name": "shared-image",
+			"tags": [],
+			"visibility": "shared",
+			"id": "07aa21a9-fa1a-430e-9a33-185be5982431",
+			"owner": "5ef70662f8b34079a6eddb8da9d75fe8",
+			"size": 13167616
+		}`)
+	})
+
+	th.Mux.HandleFunc("/images/07aa21a9-fa1a-430e-9a33-185be5982431/members/8989447062e04a818baf9e073fd04fa7", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{
+			"created_at": "2013-09-20T19:22:19Z",
+			"image_id": "07aa21a9-fa1a-430e-9a33-185be5982431",
+			"member_id": "8989447062e04a818baf9e073fd04fa7",
+			"schema": "/v2/schemas/member",
+			"status": "pending",
+			"updated_at": "2013-09-20T19:25:31Z"
+		}`)
+	})
+}
+
 // HandleImageDeleteSuccessfully test setup
 func HandleImageDeleteSuccessfully(t *testing.T) {
 	th.Mux.HandleFunc("/images/1bea47ed-f6a9-463b-b423-14b9cca9ad27", func(w http.ResponseWriter, r *http.Request) {
@@ -388,3 +453,733 @@ func HandleImageListByTagsSuccessfully(t *testing.T) {
 	}`)
 	})
 }
+
+// HandleImageListIgnoringMarker test setup for a server that never returns a
+// "next" link and serves the same full page of images whatever the marker.
+func HandleImageListIgnoringMarker(t *testing.T) {
+	th.Mux.HandleFunc("/images", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{
+			"images": [
+				{"id": "07aa21a9-fa1a-430e-9a33-185be5982431", "name": "image-1", "size": 0},
+				{"id": "8c64f48a-45a3-4eaa-adff-a8106b6c005b", "name": "image-2", "size": 0}
+			],
+			"schema": "/schemas/images",
+			"first": "/images?limit=2"
+		}`)
+	})
+}
+
+// HandleImageListWithoutNextSuccessfully test setup for a server that never
+// returns a "next" link, even when more images are available.
+func HandleImageListWithoutNextSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/images", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		r.ParseForm()
+		th.AssertEquals(t, "2", r.Form.Get("limit"))
+
+		switch marker := r.Form.Get("marker"); marker {
+		case "":
+			fmt.Fprintf(w, `{
+				"images": [
+					{"id": "07aa21a9-fa1a-430e-9a33-185be5982431", "name": "image-1", "size": 0},
+					{"id": "8c64f48a-45a3-4eaa-adff-a8106b6c005b", "name": "image-2", "size": 0}
+				],
+				"schema": "/schemas/images",
+				"first": "/images?limit=2"
+			}`)
+		case "8c64f48a-45a3-4eaa-adff-a8106b6c005b":
+			fmt.Fprintf(w, `{
+				"images": [
+					{"id": "e1b6edd4-bd9b-40ac-b010-8a6c16de4ba4", "name": "image-3", "size": 0}
+				],
+				"schema": "/schemas/images",
+				"first": "/images?limit=2"
+			}`)
+		default:
+			t.Fatalf("Unexpected marker: [%s]", marker)
+		}
+	})
+}
+
+// HandleImageListByStatusesSuccessfully test setup for a list of active or
+// deactivated images. If inFilter is false, the server rejects the "in:"
+// status filter, as an Image service older than Image API v2.5 does.
+func HandleImageListByStatusesSuccessfully(t *testing.T, inFilter bool) {
+	th.Mux.HandleFunc("/images", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		r.ParseForm()
+		status := r.Form.Get("status")
+		if status == "in:active,deactivated" && !inFilter {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `Invalid status value: in:active,deactivated`)
+			return
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		switch status {
+		case "in:active,deactivated":
+			fmt.Fprintf(w, `{
+				"images": [
+					{"id": "07aa21a9-fa1a-430e-9a33-185be5982431", "status": "active", "size": 0},
+					{"id": "8c64f48a-45a3-4eaa-adff-a8106b6c005b", "status": "deactivated", "size": 0}
+				]
+			}`)
+		case "active":
+			fmt.Fprintf(w, `{
+				"images": [
+					{"id": "07aa21a9-fa1a-430e-9a33-185be5982431", "status": "active", "size": 0},
+					{"id": "8c64f48a-45a3-4eaa-adff-a8106b6c005b", "status": "active", "size": 0}
+				]
+			}`)
+		case "deactivated":
+			fmt.Fprintf(w, `{
+				"images": [
+					{"id": "8c64f48a-45a3-4eaa-adff-a8106b6c005b", "status": "deactivated", "size": 0},
+					{"id": "e1b6edd4-bd9b-40ac-b010-8a6c16de4ba4", "status": "deactivated", "size": 0}
+				]
+			}`)
+		default:
+			t.Fatalf("Unexpected status: [%s]", status)
+		}
+	})
+}
+
+// HandleImageGetMultihashSuccessfully test setup for an image reported by a
+// multihash-capable Image service.
+func HandleImageGetMultihashSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/images/1bea47ed-f6a9-463b-b423-14b9cca9ad27", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		w.WriteHeader(http.StatusOK)
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{
+			"status": "active",
+			"name": "cirros-0.3.2-x86_64-disk",
+			"id": "1bea47ed-f6a9-463b-b423-14b9cca9ad27",
+			"checksum": "64d7c1cd2b6f60c92c14662941cb7913",
+			"os_hash_algo": "sha512",
+			"os_hash_value": "6513f21e44aa3da349f248188a44bc304a3653a04122d8fb4535423c8e1d14cd6a153f735bb0982e2161b5b5186106570c17a9e58b64dd39390617cd5a350f78",
+			"size": 13167616
+		}`)
+	})
+}
+
+// HandleImageSetProtectedSuccessfully setup
+func HandleImageSetProtectedSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/images/da3b75d9-3f4a-40e7-8a2c-bfab23927dea", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PATCH")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		th.TestJSONRequest(t, r, `[
+			{
+				"op": "replace",
+				"path": "/protected",
+				"value": true
+			}
+		]`)
+
+		th.AssertEquals(t, "application/openstack-images-v2.1-json-patch", r.Header.Get("Content-Type"))
+
+		w.WriteHeader(http.StatusOK)
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{
+			"id": "da3b75d9-3f4a-40e7-8a2c-bfab23927dea",
+			"name": "Fedora 17",
+			"status": "active",
+			"protected": true,
+			"size": 2254249
+		}`)
+	})
+}
+
+// HandleImageUpdateFormatsSuccessfully setup
+func HandleImageUpdateFormatsSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/images/b2173dd3-7ad6-4362-baa6-a68bce3565cb", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		if r.Method == "GET" {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"id": "b2173dd3-7ad6-4362-baa6-a68bce3565cb", "status": "queued", "container_format": "bare", "disk_format": "raw"}`)
+			return
+		}
+
+		th.TestMethod(t, r, "PATCH")
+		th.TestJSONRequest(t, r, `[
+			{
+				"op": "replace",
+				"path": "/container_format",
+				"value": "ovf"
+			},
+			{
+				"op": "replace",
+				"path": "/disk_format",
+				"value": "qcow2"
+			}
+		]`)
+
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"id": "b2173dd3-7ad6-4362-baa6-a68bce3565cb", "status": "queued", "container_format": "ovf", "disk_format": "qcow2"}`)
+	})
+}
+
+// HandleImageUpdateFormatsConflict setup
+func HandleImageUpdateFormatsConflict(t *testing.T) {
+	th.Mux.HandleFunc("/images/1bea47ed-f6a9-463b-b423-14b9cca9ad27", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PATCH")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		w.WriteHeader(http.StatusConflict)
+	})
+}
+
+// HandleProjectGetSuccessfully serves Identity v3 projects by ID; projects
+// not in names do not exist. Each lookup is counted in calls.
+func HandleProjectGetSuccessfully(t *testing.T, names map[string]string, calls *int32) {
+	th.Mux.HandleFunc("/projects/", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+		atomic.AddInt32(calls, 1)
+
+		id := strings.TrimPrefix(r.URL.Path, "/projects/")
+		name, ok := names[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"project": {"id": "%s", "name": "%s"}}`, id, name)
+	})
+}
+
+// HandleImageDuplicateSizeSuccessfully serves an image, and a list of it,
+// whose body repeats the size key, as a misbehaving proxy might.
+func HandleImageDuplicateSizeSuccessfully(t *testing.T) {
+	body := `{"id": "1bea47ed-f6a9-463b-b423-14b9cca9ad27", "status": "active", "size": 13167616, "size": 1024}`
+
+	th.Mux.HandleFunc("/images/1bea47ed-f6a9-463b-b423-14b9cca9ad27", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, body)
+	})
+
+	th.Mux.HandleFunc("/images", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"images": [%s]}`, body)
+	})
+}
+
+// HandleImageMetadataRoundTripSuccessfully serves an image to export, and the
+// create and update requests that import it again.
+func HandleImageMetadataRoundTripSuccessfully(t *testing.T) {
+	image := `{
+		"id": "07aa21a9-fa1a-430e-9a33-185be5982431",
+		"name": "ubuntu-18.04",
+		"status": "active",
+		"visibility": "shared",
+		"container_format": "bare",
+		"disk_format": "qcow2",
+		"min_disk": 10,
+		"min_ram": 1024,
+		"protected": true,
+		"tags": ["lts", "base"],
+		"owner": "b4eedccc6fb74fa8a7ad6b08382b852b",
+		"checksum": "2cec138d7dae2aa59038ef8c9aec2390",
+		"size": 2254249,
+		"created_at": "2018-04-05T09:45:23Z",
+		"updated_at": "2018-04-05T09:45:23Z",
+		"self": "/v2/images/07aa21a9-fa1a-430e-9a33-185be5982431",
+		"file": "/v2/images/07aa21a9-fa1a-430e-9a33-185be5982431/file",
+		"schema": "/v2/schemas/image",
+		"locations": [],
+		"os_glance_import_task": "d7b6e3b0",
+		"hw_disk_bus": "scsi",
+		"os_hidden": false,
+		"team/owner": "storage"
+	}`
+
+	th.Mux.HandleFunc("/images/07aa21a9-fa1a-430e-9a33-185be5982431", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		switch r.Method {
+		case "GET":
+		case "PATCH":
+			th.TestJSONRequest(t, r, `[
+				{"op": "replace", "path": "/tags", "value": ["base", "lts"]},
+				{"op": "add", "path": "/hw_disk_bus", "value": "scsi"},
+				{"op": "replace", "path": "/os_hidden", "value": false},
+				{"op": "add", "path": "/team~1owner", "value": "storage"},
+				{"op": "replace", "path": "/protected", "value": true}
+			]`)
+		default:
+			t.Fatalf("Unexpected method: %s", r.Method)
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, image)
+	})
+
+	th.Mux.HandleFunc("/images", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+		th.TestJSONRequest(t, r, `{
+			"id": "07aa21a9-fa1a-430e-9a33-185be5982431",
+			"name": "ubuntu-18.04",
+			"visibility": "shared",
+			"container_format": "bare",
+			"disk_format": "qcow2",
+			"min_disk": 10,
+			"min_ram": 1024
+		}`)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"id": "07aa21a9-fa1a-430e-9a33-185be5982431", "name": "ubuntu-18.04", "status": "queued"}`)
+	})
+}
+
+// HandleImageCreationUniqueNameSuccessfully test setup. The name lookup
+// finds an existing image if existing is set, and created is set if the
+// image is created.
+func HandleImageCreationUniqueNameSuccessfully(t *testing.T, existing bool, created *bool) {
+	th.Mux.HandleFunc("/images", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+		w.Header().Add("Content-Type", "application/json")
+
+		switch r.Method {
+		case "GET":
+			th.TestFormValues(t, r, map[string]string{"name": "Ubuntu 12.10"})
+			w.WriteHeader(http.StatusOK)
+			if !existing {
+				fmt.Fprintf(w, `{"images": []}`)
+				return
+			}
+			fmt.Fprintf(w, `{"images": [{"id": "4b3d1e3a-3c3d-4a2a-9d2e-5a2d5e3c0b1f", "name": "Ubuntu 12.10", "status": "active"}]}`)
+		case "POST":
+			th.TestJSONRequest(t, r, `{"name": "Ubuntu 12.10"}`)
+			*created = true
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"id": "e7db3b45-8db7-47ad-8109-3fb55c2c24fd", "name": "Ubuntu 12.10", "status": "queued"}`)
+		default:
+			t.Fatalf("Unexpected method: %s", r.Method)
+		}
+	})
+}
+
+// HandleImageSetTagsSuccessfully test setup for an image with the provided
+// tags. Deleting "old tag" fails with a 404, as if another client had removed
+// it. Each tag call is recorded in calls as "<method> <tag>".
+func HandleImageSetTagsSuccessfully(t *testing.T, tags []string, calls *[]string) {
+	image, err := json.Marshal(map[string]interface{}{
+		"id":     "1bea47ed-f6a9-463b-b423-14b9cca9ad27",
+		"name":   "cirros",
+		"status": "active",
+		"tags":   tags,
+	})
+	th.AssertNoErr(t, err)
+
+	th.Mux.HandleFunc("/images/1bea47ed-f6a9-463b-b423-14b9cca9ad27", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(image)
+	})
+
+	th.Mux.HandleFunc("/images/1bea47ed-f6a9-463b-b423-14b9cca9ad27/tags/", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		tag := strings.TrimPrefix(r.URL.Path, "/images/1bea47ed-f6a9-463b-b423-14b9cca9ad27/tags/")
+		*calls = append(*calls, r.Method+" "+tag)
+		if tag == "x86/64" {
+			th.AssertEquals(t, "/images/1bea47ed-f6a9-463b-b423-14b9cca9ad27/tags/x86%2F64", r.URL.EscapedPath())
+		}
+		if r.Method == "DELETE" && tag == "old tag" {
+			// Removed concurrently by another client.
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// HandleImageSetProtectedManySuccessfully test setup. Images other than
+// da3b75d9-3f4a-40e7-8a2c-bfab23927dea and
+// 1bea47ed-f6a9-463b-b423-14b9cca9ad27 are not found. The number of updates
+// is counted in calls.
+func HandleImageSetProtectedManySuccessfully(t *testing.T, calls *int32) {
+	th.Mux.HandleFunc("/images/", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PATCH")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+		th.TestJSONRequest(t, r, `[{"op": "replace", "path": "/protected", "value": false}]`)
+		atomic.AddInt32(calls, 1)
+
+		id := strings.TrimPrefix(r.URL.Path, "/images/")
+		if id != "da3b75d9-3f4a-40e7-8a2c-bfab23927dea" && id != "1bea47ed-f6a9-463b-b423-14b9cca9ad27" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"id": "%s", "status": "active", "protected": false}`, id)
+	})
+}
+
+// HandleImageSetVisibilityManySuccessfully test setup for a shared image
+// with two members, one of which is already gone, a private image and a
+// public image, all being made private. The requests made are recorded in
+// calls.
+func HandleImageSetVisibilityManySuccessfully(t *testing.T, calls *[]string) {
+	visibilities := map[string]string{
+		"da3b75d9-3f4a-40e7-8a2c-bfab23927dea": "shared",
+		"1bea47ed-f6a9-463b-b423-14b9cca9ad27": "private",
+		"c5d7a1b2-7a3b-4b5e-9c1d-2f6e8a9b0c3d": "public",
+	}
+
+	th.Mux.HandleFunc("/images/", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+		*calls = append(*calls, r.Method+" "+r.URL.Path)
+
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/images/"), "/")
+		id := parts[0]
+		visibility, ok := visibilities[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch {
+		case len(parts) == 2 && parts[1] == "members":
+			th.TestMethod(t, r, "GET")
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{
+				"members": [
+					{"member_id": "8989447062e04a818baf9e073fd04fa7", "status": "accepted"},
+					{"member_id": "a7b8c9d0e1f24a3b8c4d5e6f7a8b9c0d", "status": "pending"}
+				]
+			}`)
+		case len(parts) == 3 && parts[1] == "members":
+			th.TestMethod(t, r, "DELETE")
+			if parts[2] == "a7b8c9d0e1f24a3b8c4d5e6f7a8b9c0d" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "PATCH":
+			th.TestJSONRequest(t, r, `[{"op": "replace", "path": "/visibility", "value": "private"}]`)
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"id": "%s", "status": "active", "visibility": "private"}`, id)
+		default:
+			th.TestMethod(t, r, "GET")
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"id": "%s", "status": "active", "visibility": "%s"}`, id, visibility)
+		}
+	})
+}
+
+// HandleImageGetWithLocationsSuccessfully test setup for an image reported
+// with show_multiple_locations enabled.
+func HandleImageGetWithLocationsSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/images/1bea47ed-f6a9-463b-b423-14b9cca9ad27", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{
+			"id": "1bea47ed-f6a9-463b-b423-14b9cca9ad27",
+			"status": "active",
+			"locations": [
+				{
+					"url": "rbd://b3c1f2a4-7e2d-4c3b-9a8e-6f5d4c3b2a19/images/1bea47ed-f6a9-463b-b423-14b9cca9ad27/snap",
+					"metadata": {"store": "ceph"}
+				}
+			],
+			"hw_disk_bus": "scsi"
+		}`)
+	})
+}
+
+// HandleImageGetWithTypedPropertiesSuccessfully test setup for an image with
+// custom properties that are not strings.
+func HandleImageGetWithTypedPropertiesSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/images/1bea47ed-f6a9-463b-b423-14b9cca9ad27", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{
+			"id": "1bea47ed-f6a9-463b-b423-14b9cca9ad27",
+			"status": "active",
+			"hw_disk_bus": "scsi",
+			"os_require_quiesce": true,
+			"hw_rng_rate_bytes": 1024,
+			"hw_watchdog": {"action": "reset"}
+		}`)
+	})
+}
+
+// HandleImageImportSuccessfully test setup for an Image service offering
+// the glance-direct and web-download import methods.
+func HandleImageImportSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/info/import", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{
+			"import-methods": {
+				"description": "Import methods available.",
+				"type": "array",
+				"value": ["glance-direct", "web-download"]
+			}
+		}`)
+	})
+
+	th.Mux.HandleFunc("/images/1bea47ed-f6a9-463b-b423-14b9cca9ad27/import", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+		th.TestJSONRequest(t, r, `{
+			"method": {
+				"name": "web-download",
+				"uri": "https://example.com/images/bionic.img"
+			}
+		}`)
+
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+// HandleImageActionsSuccessfully test setup for deactivating and
+// reactivating an image.
+func HandleImageActionsSuccessfully(t *testing.T) {
+	for _, action := range []string{"deactivate", "reactivate"} {
+		th.Mux.HandleFunc("/images/1bea47ed-f6a9-463b-b423-14b9cca9ad27/actions/"+action, func(w http.ResponseWriter, r *http.Request) {
+			th.TestMethod(t, r, "POST")
+			th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+// HandleImageTagSuccessfully test setup for adding and deleting the
+// "channel-stable" and "café" tags. Like Glance, the handler answers 204
+// whether or not the image already has the tag.
+func HandleImageTagSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/images/1bea47ed-f6a9-463b-b423-14b9cca9ad27/tags/caf\u00e9", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" && r.Method != "DELETE" {
+			t.Fatalf("Unexpected method %s", r.Method)
+		}
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+		th.AssertEquals(t, "/images/1bea47ed-f6a9-463b-b423-14b9cca9ad27/tags/caf%C3%A9", r.URL.EscapedPath())
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	th.Mux.HandleFunc("/images/1bea47ed-f6a9-463b-b423-14b9cca9ad27/tags/channel-stable", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" && r.Method != "DELETE" {
+			t.Fatalf("Unexpected method %s", r.Method)
+		}
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// HandleImagePropertyPatchSuccessfully test setup for adding, replacing and
+// removing custom image properties.
+func HandleImagePropertyPatchSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/images/da3b75d9-3f4a-40e7-8a2c-bfab23927dea", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PATCH")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/openstack-images-v2.1-json-patch")
+		th.TestJSONRequest(t, r, `[
+			{"op": "add", "path": "/hw_disk_bus", "value": "scsi"},
+			{"op": "replace", "path": "/team~1owner", "value": "storage"},
+			{"op": "remove", "path": "/os_distro"}
+		]`)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{
+			"id": "da3b75d9-3f4a-40e7-8a2c-bfab23927dea",
+			"status": "active",
+			"hw_disk_bus": "scsi",
+			"team/owner": "storage"
+		}`)
+	})
+}
+
+// HandleListStoresSuccessfully test setup for an Image service with two
+// stores, and an image stored in both.
+func HandleListStoresSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/info/stores", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{
+			"stores": [
+				{"id": "eu-west", "description": "Ceph cluster in eu-west", "default": true},
+				{"id": "us-east", "description": "Ceph cluster in us-east"}
+			]
+		}`)
+	})
+
+	th.Mux.HandleFunc("/images/1bea47ed-f6a9-463b-b423-14b9cca9ad27", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"id": "1bea47ed-f6a9-463b-b423-14b9cca9ad27", "status": "active", "stores": "eu-west,us-east", "hw_disk_bus": "scsi"}`)
+	})
+}
+
+// ImageSchema is a trimmed-down image schema, as returned by GET
+// /v2/schemas/image.
+const ImageSchema = `
+{
+    "name": "image",
+    "additionalProperties": {
+        "type": "string"
+    },
+    "properties": {
+        "id": {
+            "type": "string",
+            "readOnly": true,
+            "description": "An identifier for the image"
+        },
+        "name": {
+            "type": ["null", "string"],
+            "maxLength": 255,
+            "description": "Descriptive name for the image"
+        },
+        "visibility": {
+            "type": "string",
+            "enum": ["community", "public", "private", "shared"],
+            "description": "Scope of image accessibility"
+        },
+        "min_ram": {
+            "type": "integer",
+            "minimum": 0,
+            "description": "Amount of ram (in MB) required to boot image."
+        },
+        "tags": {
+            "type": "array",
+            "items": {
+                "type": "string",
+                "maxLength": 255
+            },
+            "description": "List of strings related to the image"
+        },
+        "locations": {
+            "type": "array",
+            "items": {
+                "type": "object",
+                "required": ["url", "metadata"],
+                "properties": {
+                    "url": {"type": "string", "maxLength": 255},
+                    "metadata": {"type": "object"}
+                }
+            }
+        }
+    },
+    "links": [
+        {"href": "{self}", "rel": "self"},
+        {"href": "{file}", "rel": "enclosure"},
+        {"href": "{schema}", "rel": "describedby"}
+    ]
+}
+`
+
+// HandleImageSchemaSuccessfully test setup for getting the image and images
+// schemas.
+func HandleImageSchemaSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/schemas/image", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, ImageSchema)
+	})
+
+	th.Mux.HandleFunc("/schemas/images", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `
+{
+    "name": "images",
+    "properties": {
+        "images": {
+            "type": "array",
+            "items": %s
+        },
+        "schema": {"type": "string"},
+        "first": {"type": "string"},
+        "next": {"type": "string"}
+    },
+    "links": [
+        {"href": "{first}", "rel": "first"},
+        {"href": "{next}", "rel": "next"},
+        {"href": "{schema}", "rel": "describedby"}
+    ]
+}
+`, ImageSchema)
+	})
+}
+
+// HandleImageDeleteFromStoreSuccessfully test setup for deleting the data of
+// an image from the "fast" store. The image has no data in the "slow" store.
+func HandleImageDeleteFromStoreSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/stores/fast/1bea47ed-f6a9-463b-b423-14b9cca9ad27", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	th.Mux.HandleFunc("/stores/slow/1bea47ed-f6a9-463b-b423-14b9cca9ad27", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "X-Auth-Token", fakeclient.TokenID)
+
+		w.WriteHeader(http.StatusNotFound)
+	})
+}
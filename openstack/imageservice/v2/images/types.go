@@ -35,6 +35,14 @@ const (
 	// ImageStatusDeactivated denotes that access to image data is not allowed to
 	// any non-admin user.
 	ImageStatusDeactivated ImageStatus = "deactivated"
+
+	// ImageStatusImporting denotes that an import call has been made, but that
+	// the image is not yet ready for use.
+	ImageStatusImporting ImageStatus = "importing"
+
+	// ImageStatusUploading denotes that data has been staged as part of the
+	// interoperable image import process, but is not yet available for use.
+	ImageStatusUploading ImageStatus = "uploading"
 )
 
 // ImageVisibility denotes an image that is fully available in Glance.
@@ -102,3 +110,70 @@ type ImageDateQuery struct {
 	Date   time.Time
 	Filter ImageDateFilter
 }
+
+// SortDir is the direction of an ImageSort.
+type SortDir string
+
+const (
+	SortAsc  SortDir = "asc"
+	SortDesc SortDir = "desc"
+)
+
+// ImageSort is one key of a new-style sort, see ListOpts.Sorts. If Dir is
+// not set, the Image service sorts on the key in descending order.
+type ImageSort struct {
+	Key string
+	Dir SortDir
+}
+
+// TagNormalizer transforms an image tag before it is sent to, or compared
+// against tags from, the Image service. Glance compares tags byte-for-byte, so
+// the same user-visible tag in different Unicode normalization forms (such as
+// a precomposed "é" versus "e" followed by a combining accent) is treated as
+// two different tags.
+//
+// A typical TagNormalizer converts tags to Unicode Normalization Form C, for
+// example using norm.NFC.String from golang.org/x/text/unicode/norm.
+type TagNormalizer func(tag string) string
+
+// normalizeTags applies normalize to each of tags. The tags are returned as-is
+// if normalize is nil.
+func normalizeTags(tags []string, normalize TagNormalizer) []string {
+	if normalize == nil || tags == nil {
+		return tags
+	}
+
+	normalized := make([]string, len(tags))
+	for i, tag := range tags {
+		normalized[i] = normalize(tag)
+	}
+	return normalized
+}
+
+// PropertyType is the type an image property is expected to have, used to
+// coerce properties with Image.CoerceProperties.
+type PropertyType string
+
+const (
+	// PropertyTypeString is a property kept as a string.
+	PropertyTypeString PropertyType = "string"
+
+	// PropertyTypeInt is a property converted to an int.
+	PropertyTypeInt PropertyType = "int"
+
+	// PropertyTypeBool is a property converted to a bool.
+	PropertyTypeBool PropertyType = "bool"
+)
+
+// ImportMethod is a method of importing image data with Import.
+type ImportMethod string
+
+const (
+	// ImportMethodGlanceDirect imports the data uploaded with
+	// imagedata.Stage.
+	ImportMethodGlanceDirect ImportMethod = "glance-direct"
+
+	// ImportMethodWebDownload imports the data downloaded by the Image
+	// service from ImportOpts.URI.
+	ImportMethodWebDownload ImportMethod = "web-download"
+)
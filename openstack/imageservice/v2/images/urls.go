@@ -37,6 +37,40 @@ func deleteURL(c *gophercloud.ServiceClient, imageID string) string {
 	return imageURL(c, imageID)
 }
 
+// `tagURL(c,i,t)` is the URL of the tag `t` of the image identified by ID
+// `i`. The tag is escaped, since tags may contain any character.
+func tagURL(c *gophercloud.ServiceClient, imageID, tag string) string {
+	return c.ServiceURL("images", imageID, "tags", url.PathEscape(tag))
+}
+
+func storesURL(c *gophercloud.ServiceClient) string {
+	return c.ServiceURL("info", "stores")
+}
+
+func storeImageURL(c *gophercloud.ServiceClient, store, imageID string) string {
+	return c.ServiceURL("stores", store, imageID)
+}
+
+func actionURL(c *gophercloud.ServiceClient, imageID, action string) string {
+	return c.ServiceURL("images", imageID, "actions", action)
+}
+
+func importInfoURL(c *gophercloud.ServiceClient) string {
+	return c.ServiceURL("info", "import")
+}
+
+func importURL(c *gophercloud.ServiceClient, imageID string) string {
+	return c.ServiceURL("images", imageID, "import")
+}
+
+func schemaURL(c *gophercloud.ServiceClient, name string) string {
+	return c.ServiceURL("schemas", name)
+}
+
+func memberURL(c *gophercloud.ServiceClient, imageID, memberID string) string {
+	return c.ServiceURL("images", imageID, "members", memberID)
+}
+
 // builds next page full url based on current url
 func nextPageURL(currentURL string, next string) (string, error) {
 	base, err := url.Parse(currentURL)
@@ -0,0 +1,643 @@
+package images
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/projects"
+	"github.com/gophercloud/gophercloud/openstack/imageservice/v2/members"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+// WaitForStatus will continually poll the image, checking for a particular
+// status. It will do this for up to secs seconds, returning a
+// gophercloud.ErrTimeout if the status is not reached in time; if secs is
+// zero or less, the ErrTimeout is returned at once. If the image is killed
+// while waiting, an ErrImageStatus is returned.
+func WaitForStatus(c *gophercloud.ServiceClient, id string, status ImageStatus, secs int) error {
+	if secs <= 0 {
+		return gophercloud.ErrTimeout{}
+	}
+	return gophercloud.Poll(context.Background(), time.Second, time.Duration(secs)*time.Second, func() (bool, error) {
+		current, err := Get(c, id).Extract()
+		if err != nil {
+			return false, err
+		}
+
+		if current.Status == status {
+			return true, nil
+		}
+
+		if current.IsError() {
+			return false, ErrImageStatus{ImageID: id, Status: current.Status}
+		}
+
+		return false, nil
+	})
+}
+
+// VerifyUpload retrieves the image with the provided ID and compares the
+// checksum of its data against localChecksum, returning an ErrChecksumMismatch
+// if they differ.
+//
+// If the Image service supports multihash, the image's OSHashValue is used and
+// localChecksum must have been computed with the image's OSHashAlgo (sha512 by
+// default). Otherwise localChecksum is compared against the MD5 Checksum.
+func VerifyUpload(client *gophercloud.ServiceClient, imageID, localChecksum string) error {
+	image, err := Get(client, imageID).Extract()
+	if err != nil {
+		return err
+	}
+
+	algorithm, actual := "md5", image.Checksum
+	if image.OSHashValue != "" {
+		algorithm, actual = image.OSHashAlgo, image.OSHashValue
+	}
+
+	if !strings.EqualFold(localChecksum, actual) {
+		return ErrChecksumMismatch{
+			ImageID:   imageID,
+			Algorithm: algorithm,
+			Expected:  localChecksum,
+			Actual:    actual,
+		}
+	}
+
+	return nil
+}
+
+// GetWithMemberStatus retrieves the image with the provided ID. If the image
+// is shared and owned by a project other than memberID, the member record for
+// memberID is retrieved as well and its status is stored in the image's
+// MemberStatus. If memberID is empty, the client's TenantID is used.
+//
+// MemberStatus is left empty for images that are not shared, for images owned
+// by memberID, and for shared images memberID is not a member of.
+func GetWithMemberStatus(client *gophercloud.ServiceClient, id, memberID string) (*Image, error) {
+	image, err := Get(client, id).Extract()
+	if err != nil {
+		return nil, err
+	}
+
+	if memberID == "" {
+		memberID = client.TenantID
+	}
+	if image.Visibility != ImageVisibilityShared || memberID == "" || image.Owner == memberID {
+		return image, nil
+	}
+
+	var member struct {
+		Status string `json:"status"`
+	}
+	_, err = client.Get(memberURL(client, id, memberID), &member, nil)
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return image, nil
+		}
+		return nil, err
+	}
+
+	image.MemberStatus = member.Status
+	return image, nil
+}
+
+// ResolveOwnerNames looks up the names of the projects owning images, using
+// identity, an Identity v3 client, and returns them keyed by project ID. Each
+// distinct owner is looked up once, with at most concurrency lookups in
+// flight. It is typically combined with a List using
+// ListOpts{MemberStatus: ImageMemberStatusAll} to report on every image.
+//
+// Owners that no longer exist are left out of the map. If any other lookup
+// fails, the first such error is returned along with the names resolved.
+func ResolveOwnerNames(identity *gophercloud.ServiceClient, images []Image, concurrency int) (map[string]string, error) {
+	if concurrency < 1 {
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "concurrency"
+		err.Value = concurrency
+		err.Info = "concurrency must be at least 1"
+		return nil, err
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var firstErr error
+	names := make(map[string]string)
+	queue := make(chan string)
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range queue {
+				project, err := projects.Get(identity, id).Extract()
+				mu.Lock()
+				switch err.(type) {
+				case nil:
+					names[id] = project.Name
+				case gophercloud.ErrDefault404:
+				default:
+					if firstErr == nil {
+						firstErr = err
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	seen := make(map[string]bool)
+	for _, image := range images {
+		if image.Owner == "" || seen[image.Owner] {
+			continue
+		}
+		seen[image.Owner] = true
+		queue <- image.Owner
+	}
+	close(queue)
+	wg.Wait()
+
+	return names, firstErr
+}
+
+// MetadataBackupVersion is the version of the MetadataBackup format written
+// by ExportMetadata.
+const MetadataBackupVersion = 1
+
+// MetadataBackup is the image record written by ExportMetadata. The fields
+// under "Informational" describe the image data as it was when exported;
+// ImportMetadata does not restore them, since they belong to the data.
+type MetadataBackup struct {
+	Version int `json:"version"`
+
+	ID              string                 `json:"id"`
+	Name            string                 `json:"name"`
+	Visibility      ImageVisibility        `json:"visibility"`
+	ContainerFormat string                 `json:"container_format"`
+	DiskFormat      string                 `json:"disk_format"`
+	MinDisk         int                    `json:"min_disk"`
+	MinRAM          int                    `json:"min_ram"`
+	Protected       bool                   `json:"protected"`
+	Tags            []string               `json:"tags"`
+	Properties      map[string]interface{} `json:"properties"`
+
+	// Informational.
+	Owner       string      `json:"owner"`
+	Status      ImageStatus `json:"status"`
+	Checksum    string      `json:"checksum"`
+	OSHashAlgo  string      `json:"os_hash_algo"`
+	OSHashValue string      `json:"os_hash_value"`
+	SizeBytes   int64       `json:"size"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+// reservedProperties are attributes the Image service reports alongside the
+// custom properties but manages itself, so they cannot be set on import.
+var reservedProperties = map[string]bool{
+	"locations":  true,
+	"stores":     true,
+	"direct_url": true,
+}
+
+// ExportMetadata serializes the record of an image, including its tags and
+// custom properties but not its data, as a MetadataBackup in JSON. The output
+// is stable: exporting an unchanged image twice yields the same bytes.
+func ExportMetadata(client *gophercloud.ServiceClient, imageID string) ([]byte, error) {
+	image, err := Get(client, imageID).Extract()
+	if err != nil {
+		return nil, err
+	}
+
+	tags := append([]string{}, image.Tags...)
+	sort.Strings(tags)
+
+	properties := make(map[string]interface{}, len(image.Properties))
+	for k, v := range image.Properties {
+		if !reservedProperties[k] && !strings.HasPrefix(k, "os_glance") {
+			properties[k] = v
+		}
+	}
+
+	return json.MarshalIndent(MetadataBackup{
+		Version:         MetadataBackupVersion,
+		ID:              image.ID,
+		Name:            image.Name,
+		Visibility:      image.Visibility,
+		ContainerFormat: image.ContainerFormat,
+		DiskFormat:      image.DiskFormat,
+		MinDisk:         image.MinDiskGigabytes,
+		MinRAM:          image.MinRAMMegabytes,
+		Protected:       image.Protected,
+		Tags:            tags,
+		Properties:      properties,
+		Owner:           image.Owner,
+		Status:          image.Status,
+		Checksum:        image.Checksum,
+		OSHashAlgo:      image.OSHashAlgo,
+		OSHashValue:     image.OSHashValue,
+		SizeBytes:       image.SizeBytes,
+		CreatedAt:       image.CreatedAt,
+	}, "", "  ")
+}
+
+// ImportMetadata recreates an image record, with the same ID, from the output
+// of ExportMetadata. The image is created with its core attributes, then its
+// tags, custom properties, os_hidden and protection are applied with a single
+// update. If that update fails, the new image is deleted again. The image is
+// left queued; its data must be uploaded separately.
+func ImportMetadata(client *gophercloud.ServiceClient, data []byte) (*Image, error) {
+	var backup MetadataBackup
+	if err := json.Unmarshal(data, &backup); err != nil {
+		return nil, err
+	}
+	if backup.Version != MetadataBackupVersion {
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "images.MetadataBackup.Version"
+		err.Value = backup.Version
+		err.Info = fmt.Sprintf("only version %d is supported", MetadataBackupVersion)
+		return nil, err
+	}
+
+	if backup.Tags == nil {
+		backup.Tags = []string{}
+	}
+	patches := UpdateOpts{ReplaceImageTags{NewTags: backup.Tags}}
+	keys := make([]string, 0, len(backup.Properties))
+	for k := range backup.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		// os_hidden is the one property the Image service reports that is
+		// not a string.
+		if hidden, ok := backup.Properties[k].(bool); ok && k == "os_hidden" {
+			patches = append(patches, ReplaceImageHidden{NewHidden: hidden})
+			continue
+		}
+		v, ok := backup.Properties[k].(string)
+		if !ok {
+			err := gophercloud.ErrInvalidInput{}
+			err.Argument = "images.MetadataBackup.Properties"
+			err.Value = backup.Properties[k]
+			err.Info = fmt.Sprintf("property %q is not a string", k)
+			return nil, err
+		}
+		patches = append(patches, UpdateImageProperty{Op: AddOp, Name: k, Value: v})
+	}
+	if backup.Protected {
+		patches = append(patches, ReplaceImageProtected{NewProtected: true})
+	}
+
+	createOpts := CreateOpts{
+		ID:              backup.ID,
+		Name:            backup.Name,
+		ContainerFormat: backup.ContainerFormat,
+		DiskFormat:      backup.DiskFormat,
+		MinDisk:         backup.MinDisk,
+		MinRAM:          backup.MinRAM,
+	}
+	if backup.Visibility != "" {
+		createOpts.Visibility = &backup.Visibility
+	}
+	image, err := Create(client, createOpts).Extract()
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := Update(client, image.ID, patches).Extract()
+	if err != nil {
+		Delete(client, image.ID)
+		return nil, err
+	}
+	return updated, nil
+}
+
+// SetTags makes desired the tags of the image with the provided ID. Unlike a
+// ReplaceImageTags update, it changes only the tags that differ: the image
+// is retrieved, and each missing tag is added with AddTag and each extra tag
+// removed with DeleteTag. Tags that other clients add in the meantime are
+// thus kept, unless they are in the image's tags when it is retrieved and
+// not in desired.
+//
+// The diff is computed against the tags as retrieved, so a concurrent change
+// to a tag in desired between the Get and the per-tag calls may be undone:
+// a tag another client removes in that window is added back, and one it adds
+// that is not in desired is kept. A tag that is already gone when SetTags
+// deletes it is not an error.
+//
+// If normalize is set, it is applied to each of desired before the diff is
+// computed, so that the image ends up with the normalized tags: a tag stored
+// in another form, such as with a decomposed "é", is deleted as it is stored
+// and added back normalized.
+func SetTags(client *gophercloud.ServiceClient, imageID string, desired []string, normalize TagNormalizer) error {
+	image, err := Get(client, imageID).Extract()
+	if err != nil {
+		return err
+	}
+	desired = normalizeTags(desired, normalize)
+
+	current := make(map[string]bool, len(image.Tags))
+	for _, tag := range image.Tags {
+		current[tag] = true
+	}
+	wanted := make(map[string]bool, len(desired))
+	for _, tag := range desired {
+		if wanted[tag] {
+			continue
+		}
+		wanted[tag] = true
+		if !current[tag] {
+			if err := AddTag(client, imageID, tag, nil).ExtractErr(); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, tag := range image.Tags {
+		if wanted[tag] {
+			continue
+		}
+		err := DeleteTag(client, imageID, tag, nil).ExtractErr()
+		if _, ok := err.(gophercloud.ErrDefault404); err != nil && !ok {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetProtectedMany calls SetProtected for each of ids, running up to
+// concurrency updates at a time; a concurrency below 1 is treated as 1.
+// Duplicate IDs are updated once. The returned map holds the error of each
+// image that could not be updated, and is empty if all of them were.
+func SetProtectedMany(client *gophercloud.ServiceClient, ids []string, protected bool, concurrency int) map[string]error {
+	var mu sync.Mutex
+	errs := make(map[string]error)
+	forEachImage(ids, concurrency, func(id string) {
+		if err := SetProtected(client, id, protected).Err; err != nil {
+			mu.Lock()
+			errs[id] = err
+			mu.Unlock()
+		}
+	})
+	return errs
+}
+
+// forEachImage calls fn once for each distinct ID of ids, running up to
+// concurrency calls at a time; a concurrency below 1 is treated as 1. It
+// returns once all the calls have.
+func forEachImage(ids []string, concurrency int, fn func(id string)) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var wg sync.WaitGroup
+	queue := make(chan string)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range queue {
+				fn(id)
+			}
+		}()
+	}
+
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		queue <- id
+	}
+	close(queue)
+	wg.Wait()
+}
+
+// SetVisibilityManyOpts contains options for a SetVisibilityMany call.
+type SetVisibilityManyOpts struct {
+	// PruneMembers deletes the member records of images moved away from
+	// ImageVisibilityShared. The Image service keeps them otherwise, and they
+	// regain access to the image if it is shared again.
+	PruneMembers bool
+
+	// Concurrency is the number of images updated at a time; a concurrency
+	// below 1 is treated as 1.
+	Concurrency int
+}
+
+// VisibilityChange is the outcome of SetVisibilityMany for one image.
+type VisibilityChange struct {
+	// Previous is the visibility the image had.
+	Previous ImageVisibility
+
+	// Changed is false if the image already had the target visibility, or if
+	// the change failed.
+	Changed bool
+
+	// PrunedMembers are the IDs of the members that were deleted.
+	PrunedMembers []string
+
+	// Err is the error that prevented the change, if any.
+	Err error
+}
+
+// SetVisibilityMany sets the visibility of each of ids to visibility, running
+// up to opts.Concurrency updates at a time. Each image is retrieved first, and
+// images that already have the target visibility are left alone. Duplicate
+// IDs are updated once. The returned map holds the outcome for each image.
+//
+// If opts.PruneMembers is set, the members of an image moved away from
+// ImageVisibilityShared are deleted before its visibility is changed, since
+// the Image service only manages the members of shared images. If deleting a
+// member fails, the visibility is not changed, and the members deleted so far
+// are reported along with the error.
+func SetVisibilityMany(client *gophercloud.ServiceClient, ids []string, visibility ImageVisibility, opts SetVisibilityManyOpts) map[string]VisibilityChange {
+	var mu sync.Mutex
+	changes := make(map[string]VisibilityChange)
+	forEachImage(ids, opts.Concurrency, func(id string) {
+		change := setVisibility(client, id, visibility, opts.PruneMembers)
+		mu.Lock()
+		changes[id] = change
+		mu.Unlock()
+	})
+	return changes
+}
+
+// setVisibility sets the visibility of the image with the provided ID for
+// SetVisibilityMany.
+func setVisibility(client *gophercloud.ServiceClient, id string, visibility ImageVisibility, pruneMembers bool) VisibilityChange {
+	var change VisibilityChange
+	image, err := Get(client, id).Extract()
+	if err != nil {
+		change.Err = err
+		return change
+	}
+
+	change.Previous = image.Visibility
+	if image.Visibility == visibility {
+		return change
+	}
+
+	if pruneMembers && image.Visibility == ImageVisibilityShared {
+		change.PrunedMembers, change.Err = pruneImageMembers(client, id)
+		if change.Err != nil {
+			return change
+		}
+	}
+
+	change.Err = Update(client, id, UpdateOpts{UpdateVisibility{Visibility: visibility}}).Err
+	change.Changed = change.Err == nil
+	return change
+}
+
+// pruneImageMembers deletes all members of the image with the provided ID,
+// returning the IDs of those deleted. Members already gone are not an error.
+func pruneImageMembers(client *gophercloud.ServiceClient, id string) ([]string, error) {
+	allPages, err := members.List(client, id).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	allMembers, err := members.ExtractMembers(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	var pruned []string
+	for _, member := range allMembers {
+		err := members.Delete(client, id, member.MemberID).ExtractErr()
+		if _, ok := err.(gophercloud.ErrDefault404); err != nil && !ok {
+			return pruned, err
+		}
+		pruned = append(pruned, member.MemberID)
+	}
+	return pruned, nil
+}
+
+// ListWithStatuses lists all images matching opts whose status is any of
+// opts.Statuses. It first lists them with a single "in:" status filter, as
+// List does. If the Image service rejects that filter with a 400, as those
+// older than Image API v2.5 do, it falls back to one listing per status and
+// merges the results, dropping images listed more than once, e.g. because
+// their status changed between two of the listings.
+//
+// Images are returned in the order opts sorts them only when the filter is
+// applied by the server; merged results are ordered by status, in the order
+// of opts.Statuses, and then as opts sorts them.
+func ListWithStatuses(client *gophercloud.ServiceClient, opts ListOpts) ([]Image, error) {
+	if len(opts.Statuses) == 0 {
+		return listAllImages(client, opts)
+	}
+
+	all, err := listAllImages(client, opts)
+	if _, ok := err.(gophercloud.ErrDefault400); !ok {
+		return all, err
+	}
+
+	statuses := opts.Statuses
+	opts.Statuses = nil
+	var merged []Image
+	seen := make(map[string]bool)
+	for _, status := range statuses {
+		opts.Status = status
+		images, err := listAllImages(client, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, image := range images {
+			if seen[image.ID] {
+				continue
+			}
+			seen[image.ID] = true
+			merged = append(merged, image)
+		}
+	}
+	return merged, nil
+}
+
+// listAllImages returns all images listed with opts.
+func listAllImages(client *gophercloud.ServiceClient, opts ListOpts) ([]Image, error) {
+	var all []Image
+	err := List(client, opts).EachPage(func(page pagination.Page) (bool, error) {
+		images, err := ExtractImages(page)
+		if err != nil {
+			return false, err
+		}
+		all = append(all, images...)
+		return true, nil
+	})
+	return all, err
+}
+
+// ProjectImages returns a copy of images in which each image's Properties only
+// hold the properties named in keepProps. It reduces the memory held by a large
+// list of images when only a few properties are of interest: the returned
+// images share no Properties with the originals, so those are freed once images
+// is no longer referenced. images itself is left unchanged.
+//
+// An image that has none of keepProps, or no Properties at all, has nil
+// Properties in the result.
+func ProjectImages(images []Image, keepProps []string) []Image {
+	if images == nil {
+		return nil
+	}
+
+	projected := make([]Image, len(images))
+	for i, image := range images {
+		var props map[string]interface{}
+		for _, key := range keepProps {
+			v, ok := image.Properties[key]
+			if !ok {
+				continue
+			}
+			if props == nil {
+				props = make(map[string]interface{}, len(keepProps))
+			}
+			props[key] = v
+		}
+		image.Properties = props
+		projected[i] = image
+	}
+	return projected
+}
+
+// ListAll lists the images matching opts, like List, but stops once
+// maxItems images have been collected. It reports whether more images
+// remained: when the last page it needed is used up exactly, it fetches one
+// more page to find out. To avoid fetching much more than is kept, set
+// opts' Limit to at most maxItems. maxItems must be positive.
+func ListAll(client *gophercloud.ServiceClient, opts ListOptsBuilder, maxItems int) ([]Image, bool, error) {
+	if maxItems < 1 {
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "maxItems"
+		err.Value = maxItems
+		err.Info = "maxItems must be positive"
+		return nil, false, err
+	}
+
+	var all []Image
+	var more bool
+	err := List(client, opts).EachPage(func(page pagination.Page) (bool, error) {
+		images, err := ExtractImages(page)
+		if err != nil {
+			return false, err
+		}
+		if n := maxItems - len(all); len(images) > n {
+			all = append(all, images[:n]...)
+			more = true
+			return false, nil
+		}
+		all = append(all, images...)
+		return true, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return all, more, nil
+}
@@ -12,7 +12,7 @@ import (
 
 	* The specified images must exist.
 	* You can only add a new member to an image which 'visibility' attribute is
-		private.
+		shared.
 	* You must be the owner of the specified image.
 
 	Synchronous Postconditions
@@ -58,11 +58,22 @@ type UpdateOptsBuilder interface {
 
 // UpdateOpts represents options to an Update request.
 type UpdateOpts struct {
+	// Status is the new status of the member: "accepted", "rejected" or
+	// "pending".
 	Status string
 }
 
-// ToMemberUpdateMap formats an UpdateOpts structure into a request body.
+// ToImageMemberUpdateMap formats an UpdateOpts structure into a request body.
 func (opts UpdateOpts) ToImageMemberUpdateMap() (map[string]interface{}, error) {
+	switch opts.Status {
+	case "accepted", "rejected", "pending":
+	default:
+		err := gophercloud.ErrInvalidInput{}
+		err.Argument = "members.UpdateOpts.Status"
+		err.Value = opts.Status
+		err.Info = "Status must be accepted, rejected or pending"
+		return nil, err
+	}
 	return map[string]interface{}{
 		"status": opts.Status,
 	}, nil
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/imageservice/v2/members"
 	"github.com/gophercloud/gophercloud/pagination"
 	th "github.com/gophercloud/gophercloud/testhelper"
@@ -170,3 +171,10 @@ func TestMemberUpdateSuccessfully(t *testing.T) {
 	}, *im)
 
 }
+
+func TestMemberUpdateInvalidStatus(t *testing.T) {
+	_, err := members.UpdateOpts{Status: "approved"}.ToImageMemberUpdateMap()
+	if _, ok := err.(gophercloud.ErrInvalidInput); !ok {
+		t.Fatalf("Expected ErrInvalidInput, got %v", err)
+	}
+}
@@ -38,6 +38,40 @@ type Segment struct {
 	SegmentationID  int    `json:"provider:segmentation_id"`
 }
 
+// UnmarshalJSON accepts the segmentation ID as a number, a numeric string, or
+// null.
+func (r *Segment) UnmarshalJSON(b []byte) error {
+	type tmp Segment
+	var segment struct {
+		tmp
+		SegmentationID interface{} `json:"provider:segmentation_id"`
+	}
+
+	if err := json.Unmarshal(b, &segment); err != nil {
+		return err
+	}
+
+	*r = Segment(segment.tmp)
+
+	switch t := segment.SegmentationID.(type) {
+	case float64:
+		r.SegmentationID = int(t)
+	case string:
+		if t == "" {
+			break
+		}
+		id, err := strconv.Atoi(t)
+		if err != nil {
+			return err
+		}
+		r.SegmentationID = id
+	}
+
+	return nil
+}
+
+// UnmarshalJSON handles the provider attributes being absent, as they are for
+// non-admin users, and accepts the segmentation ID as a number or a string.
 func (r *NetworkProviderExt) UnmarshalJSON(b []byte) error {
 	type tmp NetworkProviderExt
 	var networkProviderExt struct {
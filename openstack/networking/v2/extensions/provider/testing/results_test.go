@@ -1,6 +1,7 @@
 package testing
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"testing"
@@ -73,6 +74,63 @@ func TestGet(t *testing.T) {
 	th.AssertEquals(t, "9876543210", s.SegmentationID)
 }
 
+func TestGetNonAdmin(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v2.0/networks/d32019d3-bc6e-4319-9c1d-6722fc136a22", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprintf(w, `
+{
+	"network": {
+		"status": "ACTIVE",
+		"subnets": [],
+		"name": "private-network",
+		"admin_state_up": true,
+		"tenant_id": "4fd44f30292945e481c7b8a0c8908869",
+		"shared": true,
+		"id": "d32019d3-bc6e-4319-9c1d-6722fc136a22"
+	}
+}
+		`)
+	})
+
+	var s struct {
+		networks.Network
+		provider.NetworkProviderExt
+	}
+
+	err := networks.Get(fake.ServiceClient(), "d32019d3-bc6e-4319-9c1d-6722fc136a22").ExtractInto(&s)
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, "d32019d3-bc6e-4319-9c1d-6722fc136a22", s.ID)
+	th.AssertEquals(t, "", s.NetworkType)
+	th.AssertEquals(t, "", s.PhysicalNetwork)
+	th.AssertEquals(t, "", s.SegmentationID)
+	th.AssertEquals(t, 0, len(s.Segments))
+}
+
+func TestSegmentUnmarshal(t *testing.T) {
+	var segments []provider.Segment
+	err := json.Unmarshal([]byte(`[
+		{"provider:segmentation_id": 666, "provider:physical_network": "br-ex", "provider:network_type": "vlan"},
+		{"provider:segmentation_id": "615", "provider:physical_network": "br-ex", "provider:network_type": "vlan"},
+		{"provider:segmentation_id": null, "provider:physical_network": null, "provider:network_type": "flat"}
+	]`), &segments)
+	th.AssertNoErr(t, err)
+
+	th.AssertDeepEquals(t, []provider.Segment{
+		{NetworkType: "vlan", PhysicalNetwork: "br-ex", SegmentationID: 666},
+		{NetworkType: "vlan", PhysicalNetwork: "br-ex", SegmentationID: 615},
+		{NetworkType: "flat"},
+	}, segments)
+}
+
 func TestCreate(t *testing.T) {
 	th.SetupHTTP()
 	defer th.TeardownHTTP()
@@ -1,9 +1,18 @@
 package testing
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"fmt"
+	"math/big"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack"
@@ -73,6 +82,75 @@ func TestAuthenticatedClientV3(t *testing.T) {
 	th.CheckEquals(t, "0ca8f6", client.UserID)
 }
 
+func TestAuthenticatedClientV3ApplicationCredential(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `
+			{
+				"versions": {
+					"values": [
+						{
+							"status": "stable",
+							"id": "v3.0",
+							"links": [
+								{ "href": "%s", "rel": "self" }
+							]
+						}
+					]
+				}
+			}
+		`, th.Endpoint()+"v3/")
+	})
+
+	th.Mux.HandleFunc("/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		th.TestJSONRequest(t, r, `
+			{
+				"auth": {
+					"identity": {
+						"methods": ["application_credential"],
+						"application_credential": {
+							"id": "a2ac4b0e",
+							"secret": "secret"
+						}
+					}
+				}
+			}`)
+
+		w.Header().Add("X-Subject-Token", ID)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `
+			{
+				"token": {
+					"expires_at": "2013-02-02T18:30:59.000000Z",
+					"project": {
+						"id": "263fd9"
+					},
+					"user": {
+						"id": "0ca8f6"
+					}
+				}
+			}`)
+	})
+
+	options := gophercloud.AuthOptions{
+		ApplicationCredentialID:     "a2ac4b0e",
+		ApplicationCredentialSecret: "secret",
+		IdentityEndpoint:            th.Endpoint(),
+	}
+	client, err := openstack.AuthenticatedClient(options)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, ID, client.TokenID)
+	th.CheckEquals(t, "263fd9", client.TenantID)
+
+	options.TenantName = "project"
+	_, err = openstack.AuthenticatedClient(options)
+	if _, ok := err.(gophercloud.ErrAppCredWithScope); !ok {
+		t.Fatalf("Expected an ErrAppCredWithScope, got %v", err)
+	}
+}
+
 func TestAuthenticatedClientV2(t *testing.T) {
 	th.SetupHTTP()
 	defer th.TeardownHTTP()
@@ -326,3 +404,98 @@ func TestAuthenticatedClientV3Fails(t *testing.T) {
 func TestAuthenticatedClientV2Fails(t *testing.T) {
 	testAuthenticatedClientFails(t, "http://bad-address.example.com/v2.0")
 }
+
+func TestAuthenticatedClientTLSConfig(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewUnstartedServer(mux)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `
+			{
+				"versions": {
+					"values": [
+						{
+							"status": "stable",
+							"id": "v3.0",
+							"links": [
+								{ "href": "%s", "rel": "self" }
+							]
+						}
+					]
+				}
+			}
+		`, server.URL+"/v3/")
+	})
+	mux.HandleFunc("/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("X-Subject-Token", ID)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"token": {"expires_at": "2013-02-02T18:30:59.000000Z", "project": {"id": "263fd9"}, "user": {"id": "0ca8f6"}}}`)
+	})
+
+	clientCert := generateClientCertificate(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert.Leaf)
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(server.Certificate())
+
+	options := gophercloud.AuthOptions{
+		Username:         "me",
+		Password:         "secret",
+		DomainName:       "default",
+		IdentityEndpoint: server.URL,
+		TLSConfig:        &tls.Config{RootCAs: rootCAs},
+	}
+	_, err := openstack.AuthenticatedClient(options)
+	if err == nil {
+		t.Fatal("Expected authentication without a client certificate to fail")
+	}
+
+	options.TLSConfig.Certificates = []tls.Certificate{clientCert}
+	client, err := openstack.AuthenticatedClient(options)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, ID, client.TokenID)
+
+	// The transport keeps the settings of http.DefaultTransport.
+	transport := client.HTTPClient.Transport.(*http.Transport)
+	def := http.DefaultTransport.(*http.Transport)
+	th.CheckEquals(t, options.TLSConfig, transport.TLSClientConfig)
+	th.CheckEquals(t, def.TLSHandshakeTimeout, transport.TLSHandshakeTimeout)
+	th.CheckEquals(t, def.IdleConnTimeout, transport.IdleConnTimeout)
+	th.CheckEquals(t, def.MaxIdleConns, transport.MaxIdleConns)
+	if transport.DialContext == nil {
+		t.Error("Expected the transport to keep the dialer of http.DefaultTransport")
+	}
+	if def.TLSClientConfig == options.TLSConfig {
+		t.Error("Expected http.DefaultTransport to be left unchanged")
+	}
+}
+
+// generateClientCertificate returns a self-signed client certificate.
+func generateClientCertificate(t *testing.T) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	th.AssertNoErr(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "gophercloud"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	th.AssertNoErr(t, err)
+
+	leaf, err := x509.ParseCertificate(der)
+	th.AssertNoErr(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
@@ -0,0 +1,25 @@
+// +build !go1.13
+
+package openstack
+
+import (
+	"crypto/tls"
+	"net/http"
+)
+
+// newTLSTransport returns a copy of http.DefaultTransport, with its timeouts
+// and connection limits, that uses config for TLS. http.Transport has no Clone
+// method before Go 1.13, so the settings are copied one by one.
+func newTLSTransport(config *tls.Config) *http.Transport {
+	def := http.DefaultTransport.(*http.Transport)
+	return &http.Transport{
+		Proxy:                 def.Proxy,
+		DialContext:           def.DialContext,
+		MaxIdleConns:          def.MaxIdleConns,
+		MaxIdleConnsPerHost:   def.MaxIdleConnsPerHost,
+		IdleConnTimeout:       def.IdleConnTimeout,
+		TLSHandshakeTimeout:   def.TLSHandshakeTimeout,
+		ExpectContinueTimeout: def.ExpectContinueTimeout,
+		TLSClientConfig:       config,
+	}
+}
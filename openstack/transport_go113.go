@@ -0,0 +1,16 @@
+// +build go1.13
+
+package openstack
+
+import (
+	"crypto/tls"
+	"net/http"
+)
+
+// newTLSTransport returns a copy of http.DefaultTransport, with its timeouts,
+// connection limits and HTTP/2 support, that uses config for TLS.
+func newTLSTransport(config *tls.Config) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = config
+	return transport
+}
@@ -0,0 +1,92 @@
+package pagination
+
+import (
+	"net/url"
+	"strconv"
+	"sync"
+)
+
+// AdaptiveLimit tunes the page size of a list request as its pages are
+// retrieved. It starts from the limit of the first page, doubles it after
+// every full page while the server keeps up, and settles on the server's
+// effective maximum once a page comes back with fewer items than requested
+// but still has a next link, which is how a server that silently caps the
+// limit behaves.
+//
+// An AdaptiveLimit is passed to a resource's ListOpts; after, or during, the
+// iteration PageSize reports the negotiated size. It must not be shared
+// between concurrent list requests.
+type AdaptiveLimit struct {
+	// Max bounds the page size. If zero, the page size grows until the
+	// server caps it.
+	Max int
+
+	mut    sync.Mutex
+	size   int
+	capped bool
+}
+
+// PageSize returns the page size negotiated so far, or 0 if no page has been
+// retrieved yet.
+func (a *AdaptiveLimit) PageSize() int {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	return a.size
+}
+
+// Capped reports whether the server was found to cap the page size, in which
+// case PageSize is the server's effective maximum.
+func (a *AdaptiveLimit) Capped() bool {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	return a.capped
+}
+
+// NextPageURL adjusts the limit of next, the URL of the page following
+// current, given the number of items current returned. It returns next
+// unchanged if it is empty or if current was not requested with a limit.
+func (a *AdaptiveLimit) NextPageURL(current *url.URL, next string, returned int) (string, error) {
+	if current == nil {
+		return next, nil
+	}
+	requested, err := strconv.Atoi(current.Query().Get("limit"))
+	if err != nil || requested <= 0 {
+		return next, nil
+	}
+
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	if next == "" {
+		if a.size == 0 {
+			a.size = requested
+		}
+		return "", nil
+	}
+
+	switch {
+	case returned < requested:
+		if returned > 0 {
+			a.capped = true
+			a.size = returned
+		} else {
+			a.size = requested
+		}
+	case a.capped:
+		a.size = requested
+	default:
+		a.size = requested * 2
+		if a.Max > 0 && a.size > a.Max {
+			a.size = a.Max
+		}
+	}
+
+	u, err := url.Parse(next)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	q.Set("limit", strconv.Itoa(a.size))
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
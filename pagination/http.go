@@ -19,6 +19,12 @@ type PageResult struct {
 // PageResultFrom parses an HTTP response as JSON and returns a PageResult containing the
 // results, interpreting it as JSON if the content type indicates.
 func PageResultFrom(resp *http.Response) (PageResult, error) {
+	return pageResultFrom(resp, nil)
+}
+
+// pageResultFrom is PageResultFrom, checking a JSON body for duplicates of
+// strictKeys first.
+func pageResultFrom(resp *http.Response, strictKeys []string) (PageResult, error) {
 	var parsedBody interface{}
 
 	defer resp.Body.Close()
@@ -28,6 +34,11 @@ func PageResultFrom(resp *http.Response) (PageResult, error) {
 	}
 
 	if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		if len(strictKeys) > 0 {
+			if err := gophercloud.CheckDuplicateKeys(rawBody, strictKeys); err != nil {
+				return PageResult{}, err
+			}
+		}
 		err = json.Unmarshal(rawBody, &parsedBody)
 		if err != nil {
 			return PageResult{}, err
@@ -13,6 +13,11 @@ import (
 var (
 	// ErrPageNotAvailable is returned from a Pager when a next or previous page is requested, but does not exist.
 	ErrPageNotAvailable = errors.New("The requested page does not exist.")
+
+	// ErrMarkerNotAdvancing is returned from a Pager when the page after a
+	// marker ends with that same marker, as when the server ignores the marker
+	// and keeps returning the same page.
+	ErrMarkerNotAdvancing = errors.New("The next page marker is the same as the current one.")
 )
 
 // Page must be satisfied by the result type of any resource collection.
@@ -73,7 +78,11 @@ func (p Pager) fetchNextPage(url string) (Page, error) {
 		return nil, err
 	}
 
-	remembered, err := PageResultFrom(resp)
+	var strictKeys []string
+	if p.client != nil {
+		strictKeys = p.client.StrictKeys
+	}
+	remembered, err := pageResultFrom(resp, strictKeys)
 	if err != nil {
 		return nil, err
 	}
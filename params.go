@@ -337,7 +337,7 @@ func BuildQueryString(opts interface{}) (*url.URL, error) {
 						goto loop
 					case reflect.String:
 						params.Add(tags[0], v.String())
-					case reflect.Int:
+					case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 						params.Add(tags[0], strconv.FormatInt(v.Int(), 10))
 					case reflect.Bool:
 						params.Add(tags[0], strconv.FormatBool(v.Bool()))
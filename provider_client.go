@@ -2,12 +2,14 @@ package gophercloud
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 )
 
 // DefaultUserAgent is the default User-Agent string set in the request header.
@@ -77,11 +79,39 @@ type ProviderClient struct {
 	// authentication functions for different Identity service versions.
 	ReauthFunc func() error
 
+	// Metrics, if set, is notified after every request issued through a
+	// ServiceClient built from this provider.
+	Metrics Metrics
+
+	// CircuitBreaker, if set, fails requests to an endpoint fast while that
+	// endpoint keeps failing. See CircuitBreaker for details.
+	CircuitBreaker *CircuitBreaker
+
+	// RetryPolicy, if set, retries requests that are throttled or that find
+	// the service temporarily unavailable. See RetryPolicy for details.
+	RetryPolicy *RetryPolicy
+
+	// MaxErrorBodySize, if positive, bounds the number of bytes of an error
+	// response's body that are kept in ErrUnexpectedResponseCode.Body. By
+	// default the whole body is kept.
+	MaxErrorBodySize int64
+
 	mut *sync.RWMutex
 
 	reauthmut *reauthlock
 }
 
+// Metrics receives latency and status information about completed requests.
+// Implementations must be safe for concurrent use if the ProviderClient is.
+type Metrics interface {
+	// ObserveRequest is called once a request has completed. service is the
+	// ServiceClient's Type (e.g. "image" or "volumev3") and path is the URL
+	// path of the request. status is the HTTP response code, or 0 if no
+	// response was received. Reauthentication retries are not observed
+	// separately.
+	ObserveRequest(service, method, path string, status int, dur time.Duration)
+}
+
 type reauthlock struct {
 	sync.RWMutex
 	reauthing bool
@@ -184,13 +214,41 @@ type RequestOpts struct {
 	// ErrorContext specifies the resource error type to return if an error is encountered.
 	// This lets resources override default error messages based on the response status code.
 	ErrorContext error
+	// StrictKeys lists keys that must not appear more than once in any object of the response
+	// body parsed into JSONResponse. A duplicate causes an ErrDuplicateKey instead of the last
+	// value silently winning. See CheckDuplicateKeys.
+	StrictKeys []string
+
+	// authToken, if set, is sent as the X-Auth-Token instead of the provider's token. It is set
+	// by ServiceClient.WithAuthToken.
+	authToken string
+
+	// context, if set, is the context of the HTTP request. It is set by ServiceClient.WithContext.
+	context context.Context
 }
 
 var applicationJSON = "application/json"
 
 // Request performs an HTTP request using the ProviderClient's current HTTPClient. An authentication
-// header will automatically be provided.
+// header will automatically be provided. The request is retried as the client's RetryPolicy allows.
 func (client *ProviderClient) Request(method, url string, options *RequestOpts) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := client.request(method, url, options)
+		if err == nil || client.RetryPolicy == nil {
+			return resp, err
+		}
+		d, ok := client.RetryPolicy.wait(method, options, resp, attempt)
+		if !ok {
+			return resp, err
+		}
+		if err := sleep(options.context, d); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// request performs a single attempt of a Request.
+func (client *ProviderClient) request(method, url string, options *RequestOpts) (*http.Response, error) {
 	var body io.Reader
 	var contentType *string
 
@@ -219,6 +277,9 @@ func (client *ProviderClient) Request(method, url string, options *RequestOpts)
 	if err != nil {
 		return nil, err
 	}
+	if options.context != nil {
+		req = req.WithContext(options.context)
+	}
 
 	// Populate the request headers. Apply options.MoreHeaders last, to give the caller the chance to
 	// modify or omit any header.
@@ -240,9 +301,13 @@ func (client *ProviderClient) Request(method, url string, options *RequestOpts)
 		}
 	}
 
-	// get latest token from client
-	for k, v := range client.AuthenticatedHeaders() {
-		req.Header.Set(k, v)
+	// get latest token from client, unless the caller explicitly chose another one
+	if options.authToken != "" {
+		req.Header.Set("X-Auth-Token", options.authToken)
+	} else {
+		for k, v := range client.AuthenticatedHeaders() {
+			req.Header.Set(k, v)
+		}
 	}
 
 	// Set connection parameter to close the connection immediately when we've got the response
@@ -250,8 +315,18 @@ func (client *ProviderClient) Request(method, url string, options *RequestOpts)
 
 	prereqtok := req.Header.Get("X-Auth-Token")
 
-	// Issue the request.
+	// Issue the request, unless the circuit for its endpoint is open.
+	var endpoint string
+	if client.CircuitBreaker != nil {
+		endpoint = circuitKey(url)
+		if err := client.CircuitBreaker.allow(endpoint); err != nil {
+			return nil, err
+		}
+	}
 	resp, err := client.HTTPClient.Do(req)
+	if client.CircuitBreaker != nil {
+		client.CircuitBreaker.record(req.Context(), endpoint, resp, err)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -271,14 +346,15 @@ func (client *ProviderClient) Request(method, url string, options *RequestOpts)
 	}
 
 	if !ok {
-		body, _ := ioutil.ReadAll(resp.Body)
+		body, truncated := readErrorBody(resp.Body, client.MaxErrorBodySize)
 		resp.Body.Close()
 		respErr := ErrUnexpectedResponseCode{
-			URL:      url,
-			Method:   method,
-			Expected: options.OkCodes,
-			Actual:   resp.StatusCode,
-			Body:     body,
+			URL:           url,
+			Method:        method,
+			Expected:      options.OkCodes,
+			Actual:        resp.StatusCode,
+			Body:          body,
+			BodyTruncated: truncated,
 		}
 
 		errType := options.ErrorContext
@@ -289,7 +365,7 @@ func (client *ProviderClient) Request(method, url string, options *RequestOpts)
 				err = error400er.Error400(respErr)
 			}
 		case http.StatusUnauthorized:
-			if client.ReauthFunc != nil {
+			if client.ReauthFunc != nil && options.authToken == "" {
 				err = client.Reauthenticate(prereqtok)
 				if err != nil {
 					e := &ErrUnableToReauthenticate{}
@@ -367,7 +443,21 @@ func (client *ProviderClient) Request(method, url string, options *RequestOpts)
 	// Parse the response body as JSON, if requested to do so.
 	if options.JSONResponse != nil {
 		defer resp.Body.Close()
-		if err := json.NewDecoder(resp.Body).Decode(options.JSONResponse); err != nil {
+		if len(options.StrictKeys) == 0 {
+			if err := json.NewDecoder(resp.Body).Decode(options.JSONResponse); err != nil {
+				return nil, err
+			}
+			return resp, nil
+		}
+
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		if err := CheckDuplicateKeys(body, options.StrictKeys); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(body, options.JSONResponse); err != nil {
 			return nil, err
 		}
 	}
@@ -375,6 +465,24 @@ func (client *ProviderClient) Request(method, url string, options *RequestOpts)
 	return resp, nil
 }
 
+// readErrorBody reads the body of an error response, whatever its content
+// type, up to max bytes if max is positive. It reports whether the body was
+// cut short, either by max or by an error reading it; what was read before
+// the error is still returned.
+func readErrorBody(r io.Reader, max int64) ([]byte, bool) {
+	if max > 0 {
+		r = io.LimitReader(r, max+1)
+	}
+	body, err := ioutil.ReadAll(r)
+	if err != nil {
+		return body, true
+	}
+	if max > 0 && int64(len(body)) > max {
+		return body[:max], true
+	}
+	return body, false
+}
+
 func defaultOkCodes(method string) []int {
 	switch {
 	case method == "GET":
@@ -0,0 +1,35 @@
+package gophercloud
+
+import (
+	"crypto/rand"
+	"fmt"
+	"regexp"
+)
+
+// RequestIDHeader is the header a client sends to give a request its own
+// request ID, and that OpenStack services use to return the ID of a request.
+const RequestIDHeader = "X-OpenStack-Request-ID"
+
+// requestIDPattern is the form of request ID the OpenStack services accept
+// from clients: "req-" followed by a UUID.
+var requestIDPattern = regexp.MustCompile(`^req-[a-f0-9]{8}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{12}$`)
+
+// NewRequestID returns a new random request ID of the form the OpenStack
+// services accept, "req-<uuid>". To make a create request retriable, generate
+// the ID once and pass the same ID to every attempt.
+func NewRequestID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	// Version 4, variant 10.
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("req-%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// IsRequestID reports whether id has the form of a request ID the OpenStack
+// services accept. Services ignore a client-supplied ID of any other form.
+func IsRequestID(id string) bool {
+	return requestIDPattern.MatchString(id)
+}
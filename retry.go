@@ -0,0 +1,142 @@
+package gophercloud
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Default values for the RetryPolicy settings.
+const (
+	DefaultRetryBackoff    = time.Second
+	DefaultRetryMaxBackoff = 30 * time.Second
+)
+
+// RetryPolicy makes a ProviderClient re-issue requests that are throttled or
+// that find the service temporarily unavailable, that is requests answered
+// with a 429 or a 503. Before each retry, the client waits for as long as the
+// response's Retry-After header asks, or else for Backoff, doubled after every
+// attempt. Either wait is capped at MaxBackoff.
+//
+// Only requests using one of Methods are retried, so that a request that may
+// have taken effect is not repeated: by default GET and HEAD requests, which
+// includes listing. A request whose RawBody cannot be rewound is never
+// retried. If the request has a context, see ServiceClient.WithContext, the
+// wait ends early once the context is done.
+//
+// The error of the last attempt is returned once MaxRetries retries have
+// failed too.
+type RetryPolicy struct {
+	// MaxRetries is the number of times a request is retried after its first
+	// attempt.
+	MaxRetries int
+
+	// Backoff is the wait before the first retry when the response does not
+	// say how long to wait. It defaults to DefaultRetryBackoff.
+	Backoff time.Duration
+
+	// MaxBackoff caps the wait before any retry. It defaults to
+	// DefaultRetryMaxBackoff.
+	MaxBackoff time.Duration
+
+	// Methods lists the HTTP methods of the requests to retry. It defaults to
+	// GET and HEAD; only add methods whose requests are safe to repeat.
+	Methods []string
+}
+
+func (rp *RetryPolicy) backoff() time.Duration {
+	if rp.Backoff > 0 {
+		return rp.Backoff
+	}
+	return DefaultRetryBackoff
+}
+
+func (rp *RetryPolicy) maxBackoff() time.Duration {
+	if rp.MaxBackoff > 0 {
+		return rp.MaxBackoff
+	}
+	return DefaultRetryMaxBackoff
+}
+
+func (rp *RetryPolicy) retries(method string) bool {
+	if len(rp.Methods) == 0 {
+		return method == "GET" || method == "HEAD"
+	}
+	for _, m := range rp.Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// wait returns how long to wait before retrying a request that got resp on
+// its attempt'th retry, counting from 0. It reports false if the request must
+// not be retried.
+func (rp *RetryPolicy) wait(method string, options *RequestOpts, resp *http.Response, attempt int) (time.Duration, bool) {
+	if resp == nil || attempt >= rp.MaxRetries || !rp.retries(method) {
+		return 0, false
+	}
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+	if options.RawBody != nil {
+		seeker, ok := options.RawBody.(io.Seeker)
+		if !ok {
+			return 0, false
+		}
+		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+			return 0, false
+		}
+	}
+
+	d, ok := retryAfter(resp.Header.Get("Retry-After"))
+	if !ok {
+		d = rp.backoff() << uint(attempt)
+	}
+	if max := rp.maxBackoff(); d > max || d < 0 {
+		d = max
+	}
+	return d, true
+}
+
+// retryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// sleep waits for d, or until ctx is done, in which case it returns ctx's
+// error.
+func sleep(ctx context.Context, d time.Duration) error {
+	if ctx == nil {
+		time.Sleep(d)
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
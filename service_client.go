@@ -1,9 +1,15 @@
 package gophercloud
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"io"
+	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
 )
 
 // ServiceClient stores details required to interact with a specific service API implemented by a provider.
@@ -32,6 +38,17 @@ type ServiceClient struct {
 	// MoreHeaders allows users (or Gophercloud) to set service-wide headers on requests. Put another way,
 	// values set in this field will be set on all the HTTP requests the service client sends.
 	MoreHeaders map[string]string
+
+	// authToken is set by WithAuthToken.
+	authToken string
+
+	// context is set by WithContext.
+	context context.Context
+
+	// StrictKeys, if set, makes every JSON response parsed by this client, including pages of
+	// results, fail with an ErrDuplicateKey if an object in it repeats one of these keys. Some
+	// resource packages export a suitable list of critical keys, e.g. images.StrictKeys.
+	StrictKeys []string
 }
 
 // ResourceBaseURL returns the base URL of any resources used by this service. It MUST end with a /.
@@ -42,6 +59,71 @@ func (client *ServiceClient) ResourceBaseURL() string {
 	return client.Endpoint
 }
 
+// WithMoreHeaders returns a copy of the client that also sends headers with
+// every request, so that a header can be added for a single call without
+// changing the client:
+//
+//	image, err := images.Get(client.WithMoreHeaders(map[string]string{
+//		"OpenStack-API-Version": "image 2.7",
+//	}), id).Extract()
+//
+// The headers take precedence over the client's MoreHeaders and over the
+// headers Gophercloud sets itself, such as Accept or the microversion header,
+// and a blank value suppresses a header. The X-Auth-Token header is the
+// exception: it is always set from the provider's token. Use WithAuthToken to
+// send a different token on purpose.
+func (client *ServiceClient) WithMoreHeaders(headers map[string]string) *ServiceClient {
+	c := *client
+	c.MoreHeaders = make(map[string]string, len(client.MoreHeaders)+len(headers))
+	for k, v := range client.MoreHeaders {
+		c.MoreHeaders[k] = v
+	}
+	for k, v := range headers {
+		c.MoreHeaders[k] = v
+	}
+	return &c
+}
+
+// WithMicroversion returns a copy of the client that requests microversion of
+// the service, so that a call needing a newer microversion can be made without
+// changing the client:
+//
+//	volume, err := volumes.Create(client.WithMicroversion("3.50"), volumes.CreateOpts{
+//		Size:        10,
+//		Multiattach: true,
+//	}).Extract()
+func (client *ServiceClient) WithMicroversion(microversion string) *ServiceClient {
+	c := *client
+	c.Microversion = microversion
+	return &c
+}
+
+// WithAuthToken returns a copy of the client that authenticates its requests
+// with token instead of the provider's token. Requests made with the copy are
+// not reauthenticated when the token is rejected.
+func (client *ServiceClient) WithAuthToken(token string) *ServiceClient {
+	c := *client
+	c.authToken = token
+	return &c
+}
+
+// WithContext returns a copy of the client whose requests are made with ctx,
+// so that a call can be cancelled, or given a deadline, without changing the
+// client:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+//	defer cancel()
+//	allPages, err := volumes.List(client.WithContext(ctx), nil).AllPages()
+//
+// Once ctx is done, a request in flight fails with ctx's error, and so does
+// reading the body of a response that is streamed to the caller, such as an
+// image download.
+func (client *ServiceClient) WithContext(ctx context.Context) *ServiceClient {
+	c := *client
+	c.context = ctx
+	return &c
+}
+
 // ServiceURL constructs a URL for a resource belonging to this provider.
 func (client *ServiceClient) ServiceURL(parts ...string) string {
 	return client.ResourceBaseURL() + strings.Join(parts, "/")
@@ -65,6 +147,10 @@ func (client *ServiceClient) initReqOpts(url string, JSONBody interface{}, JSONR
 	if client.Microversion != "" {
 		client.setMicroversionHeader(opts)
 	}
+
+	if opts.StrictKeys == nil {
+		opts.StrictKeys = client.StrictKeys
+	}
 }
 
 // Get calls `Request` with the "GET" HTTP verb.
@@ -121,14 +207,67 @@ func (client *ServiceClient) Head(url string, opts *RequestOpts) (*http.Response
 	return client.Request("HEAD", url, opts)
 }
 
+// RequestResult issues a request for an API that Gophercloud does not model
+// yet. url is typically built with ServiceURL, and JSONBody, if not nil, is
+// sent as the JSON request body (or as-is if it is an io.Reader). The
+// request is authenticated, honors the client's Microversion and
+// MoreHeaders, and is reauthenticated on a 401 like any other request.
+//
+// The returned Result holds the decoded JSON response body, if there is one,
+// and the response headers, so ExtractInto and ExtractIntoStructPtr can be
+// used on it. Unexpected status codes produce the usual ErrDefault* errors in
+// its Err field.
+//
+//	var s struct {
+//		Widget struct {
+//			ID string `json:"id"`
+//		} `json:"widget"`
+//	}
+//	err := client.RequestResult("GET", client.ServiceURL("widgets", id), nil, nil).ExtractInto(&s)
+func (client *ServiceClient) RequestResult(method, url string, JSONBody interface{}, opts *RequestOpts) (r Result) {
+	if opts == nil {
+		opts = new(RequestOpts)
+	}
+	client.initReqOpts(url, JSONBody, nil, opts)
+
+	resp, err := client.Request(method, url, opts)
+	if resp != nil {
+		r.Header = resp.Header
+	}
+	if err != nil {
+		r.Err = err
+		return
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		r.Err = err
+		return
+	}
+	if len(bytes.TrimSpace(body)) > 0 {
+		if len(opts.StrictKeys) > 0 {
+			if r.Err = CheckDuplicateKeys(body, opts.StrictKeys); r.Err != nil {
+				return
+			}
+		}
+		r.Err = json.Unmarshal(body, &r.Body)
+	}
+	return
+}
+
 func (client *ServiceClient) setMicroversionHeader(opts *RequestOpts) {
 	switch client.Type {
 	case "compute":
 		opts.MoreHeaders["X-OpenStack-Nova-API-Version"] = client.Microversion
 	case "sharev2":
 		opts.MoreHeaders["X-OpenStack-Manila-API-Version"] = client.Microversion
-	case "volume":
+	case "volume", "volumev2", "volumev3":
+		// Cinder only knows itself as "volume", whatever the version of the
+		// client.
 		opts.MoreHeaders["X-OpenStack-Volume-API-Version"] = client.Microversion
+		opts.MoreHeaders["OpenStack-API-Version"] = "volume " + client.Microversion
+		return
 	}
 
 	if client.Type != "" {
@@ -142,9 +281,50 @@ func (client *ServiceClient) Request(method, url string, options *RequestOpts) (
 		if options == nil {
 			options = new(RequestOpts)
 		}
+		if options.MoreHeaders == nil {
+			options.MoreHeaders = make(map[string]string)
+		}
 		for k, v := range client.MoreHeaders {
 			options.MoreHeaders[k] = v
 		}
 	}
-	return client.ProviderClient.Request(method, url, options)
+	if client.authToken != "" {
+		if options == nil {
+			options = new(RequestOpts)
+		}
+		options.authToken = client.authToken
+	}
+	if client.context != nil {
+		if options == nil {
+			options = new(RequestOpts)
+		}
+		options.context = client.context
+	}
+	if client.Metrics == nil {
+		return client.ProviderClient.Request(method, url, options)
+	}
+
+	start := time.Now()
+	resp, err := client.ProviderClient.Request(method, url, options)
+	client.observeRequest(method, url, resp, err, time.Since(start))
+	return resp, err
+}
+
+// observeRequest reports a completed request to the provider's Metrics hook.
+func (client *ServiceClient) observeRequest(method, rawURL string, resp *http.Response, err error, dur time.Duration) {
+	path := rawURL
+	if u, perr := url.Parse(rawURL); perr == nil {
+		path = u.Path
+	}
+
+	var status int
+	if resp != nil {
+		status = resp.StatusCode
+	} else if sc, ok := err.(interface {
+		GetStatusCode() int
+	}); ok {
+		status = sc.GetStatusCode()
+	}
+
+	client.Metrics.ObserveRequest(client.Type, method, path, status, dur)
 }
@@ -0,0 +1,81 @@
+package testing
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+	th "github.com/gophercloud/gophercloud/testhelper"
+)
+
+func TestExtractFault(t *testing.T) {
+	cases := []struct {
+		status   int
+		body     string
+		expected gophercloud.FaultDetail
+	}{
+		{
+			400,
+			`{"badRequest": {"message": "Invalid input received: Availability zone 'nova2' is invalid.", "code": 400}}`,
+			gophercloud.FaultDetail{Type: "badRequest", Code: 400, Message: "Invalid input received: Availability zone 'nova2' is invalid."},
+		},
+		{
+			404,
+			`{"itemNotFound": {"message": "Volume 1234 could not be found.", "code": 404}}`,
+			gophercloud.FaultDetail{Type: "itemNotFound", Code: 404, Message: "Volume 1234 could not be found."},
+		},
+		{
+			401,
+			`{"error": {"message": "The request you have made requires authentication.", "code": 401, "title": "Unauthorized"}}`,
+			gophercloud.FaultDetail{Type: "error", Code: 401, Message: "The request you have made requires authentication."},
+		},
+		{
+			409,
+			`{"message": "Image status transition from active to queued is not allowed<br /><br />\n\n\n", "code": "409 Conflict", "title": "Conflict"}`,
+			gophercloud.FaultDetail{Code: 409, Message: "Image status transition from active to queued is not allowed"},
+		},
+		{
+			500,
+			`{"computeFault": {"message": "Unexpected error."}}`,
+			gophercloud.FaultDetail{Type: "computeFault", Code: 500, Message: "Unexpected error."},
+		},
+	}
+
+	for _, c := range cases {
+		respErr := gophercloud.ErrUnexpectedResponseCode{Actual: c.status, Body: []byte(c.body)}
+		fault, ok := gophercloud.ExtractFault(gophercloud.ErrDefault400{ErrUnexpectedResponseCode: respErr})
+		th.AssertEquals(t, true, ok)
+		th.AssertDeepEquals(t, c.expected, fault)
+	}
+}
+
+func TestExtractFaultWrapped(t *testing.T) {
+	respErr := gophercloud.ErrUnexpectedResponseCode{
+		Actual: 404,
+		Body:   []byte(`{"itemNotFound": {"message": "Volume 1234 could not be found.", "code": 404}}`),
+	}
+
+	for _, err := range []error{
+		respErr,
+		&respErr,
+		gophercloud.ErrDefault404{ErrUnexpectedResponseCode: respErr},
+		&gophercloud.ErrErrorAfterReauthentication{ErrOriginal: gophercloud.ErrDefault404{ErrUnexpectedResponseCode: respErr}},
+	} {
+		fault, ok := gophercloud.ExtractFault(err)
+		th.AssertEquals(t, true, ok)
+		th.AssertEquals(t, "Volume 1234 could not be found.", fault.Message)
+	}
+}
+
+func TestExtractFaultNone(t *testing.T) {
+	for _, err := range []error{
+		nil,
+		errors.New("connection refused"),
+		gophercloud.ErrDefault500{ErrUnexpectedResponseCode: gophercloud.ErrUnexpectedResponseCode{Actual: 500, Body: []byte("<html>Internal Server Error</html>")}},
+		gophercloud.ErrDefault400{ErrUnexpectedResponseCode: gophercloud.ErrUnexpectedResponseCode{Actual: 400, Body: []byte(`{"volume": {"id": "1234"}}`)}},
+	} {
+		if _, ok := gophercloud.ExtractFault(err); ok {
+			t.Errorf("Expected no fault in %v", err)
+		}
+	}
+}
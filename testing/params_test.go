@@ -88,6 +88,20 @@ func TestBuildQueryString(t *testing.T) {
 	}
 }
 
+func TestBuildQueryStringSizedInts(t *testing.T) {
+	opts := struct {
+		I64 int64 `q:"i64"`
+		I32 int32 `q:"i32"`
+	}{
+		I64: 1 << 40,
+		I32: 7,
+	}
+	expected := &url.URL{RawQuery: "i32=7&i64=1099511627776"}
+	actual, err := gophercloud.BuildQueryString(&opts)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, expected, actual)
+}
+
 func TestBuildHeaders(t *testing.T) {
 	testStruct := struct {
 		Accept string `h:"Accept"`
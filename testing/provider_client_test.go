@@ -1,9 +1,12 @@
 package testing
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -122,3 +125,239 @@ func TestConcurrentReauth(t *testing.T) {
 
 	th.AssertEquals(t, 1, info.numreauths)
 }
+
+func TestCircuitBreaker(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var mut sync.Mutex
+	var hits int
+	down := true
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		mut.Lock()
+		defer mut.Unlock()
+		hits++
+		if down {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	p := &gophercloud.ProviderClient{
+		CircuitBreaker: &gophercloud.CircuitBreaker{Threshold: 2, Cooldown: 50 * time.Millisecond},
+	}
+	request := func() error {
+		_, err := p.Request("GET", th.Endpoint()+"route", &gophercloud.RequestOpts{})
+		return err
+	}
+	assertOpen := func(err error) {
+		if _, ok := err.(gophercloud.ErrCircuitOpen); !ok {
+			t.Fatalf("Expected ErrCircuitOpen, got %v", err)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, ok := request().(gophercloud.ErrDefault503); !ok {
+			t.Fatalf("Expected ErrDefault503")
+		}
+	}
+	assertOpen(request())
+	th.AssertEquals(t, 2, hits)
+
+	// The probe after the cooldown fails, so the circuit reopens.
+	time.Sleep(60 * time.Millisecond)
+	if _, ok := request().(gophercloud.ErrDefault503); !ok {
+		t.Fatalf("Expected ErrDefault503")
+	}
+	assertOpen(request())
+	th.AssertEquals(t, 3, hits)
+
+	// The next probe succeeds and closes the circuit.
+	time.Sleep(60 * time.Millisecond)
+	mut.Lock()
+	down = false
+	mut.Unlock()
+	th.AssertNoErr(t, request())
+	th.AssertNoErr(t, request())
+	th.AssertEquals(t, 5, hits)
+}
+
+func TestCircuitBreakerIgnoresCancelledRequests(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	block := make(chan struct{})
+	defer close(block)
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-block:
+		case <-r.Context().Done():
+		}
+	})
+
+	p := &gophercloud.ProviderClient{
+		CircuitBreaker: &gophercloud.CircuitBreaker{Threshold: 1},
+	}
+	sc := &gophercloud.ServiceClient{ProviderClient: p}
+
+	for i := 0; i < 2; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		_, err := sc.WithContext(ctx).Get(th.Endpoint()+"route", nil, nil)
+		cancel()
+		if _, ok := err.(gophercloud.ErrCircuitOpen); ok || err == nil {
+			t.Fatalf("Expected the request to time out, got %v", err)
+		}
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var mut sync.Mutex
+	var hits, failures int
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		mut.Lock()
+		defer mut.Unlock()
+		hits++
+		if hits <= failures {
+			if hits%2 == 1 {
+				w.Header().Set("Retry-After", "120")
+				w.WriteHeader(http.StatusTooManyRequests)
+			} else {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	reset := func(n int) {
+		mut.Lock()
+		defer mut.Unlock()
+		hits, failures = 0, n
+	}
+
+	p := &gophercloud.ProviderClient{
+		RetryPolicy: &gophercloud.RetryPolicy{
+			MaxRetries: 2,
+			Backoff:    time.Millisecond,
+			MaxBackoff: 5 * time.Millisecond,
+		},
+	}
+
+	// The Retry-After of 120 seconds is capped by MaxBackoff.
+	reset(2)
+	start := time.Now()
+	_, err := p.Request("GET", th.Endpoint()+"route", &gophercloud.RequestOpts{})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 3, hits)
+	if time.Since(start) > time.Second {
+		t.Fatalf("Expected MaxBackoff to cap the wait, took %s", time.Since(start))
+	}
+
+	// Retries are exhausted.
+	reset(10)
+	_, err = p.Request("GET", th.Endpoint()+"route", &gophercloud.RequestOpts{})
+	if _, ok := err.(gophercloud.ErrDefault429); !ok {
+		t.Fatalf("Expected ErrDefault429, got %v", err)
+	}
+	th.AssertEquals(t, 3, hits)
+
+	// POST requests are not retried by default.
+	reset(10)
+	_, err = p.Request("POST", th.Endpoint()+"route", &gophercloud.RequestOpts{OkCodes: []int{200}})
+	if _, ok := err.(gophercloud.ErrDefault429); !ok {
+		t.Fatalf("Expected ErrDefault429, got %v", err)
+	}
+	th.AssertEquals(t, 1, hits)
+
+	// The wait ends once the request's context is done.
+	p.RetryPolicy.MaxBackoff = time.Hour
+	reset(10)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	sc := &gophercloud.ServiceClient{ProviderClient: p}
+	_, err = sc.WithContext(ctx).Get(th.Endpoint()+"route", nil, nil)
+	th.AssertEquals(t, context.DeadlineExceeded, err)
+	th.AssertEquals(t, 1, hits)
+}
+
+func TestErrorBody(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	trace := strings.Repeat("Traceback (most recent call last):\n", 1<<15)
+	th.Mux.HandleFunc("/debug", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, trace)
+	})
+	th.Mux.HandleFunc("/reset", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "100")
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, "partial")
+	})
+
+	p := new(gophercloud.ProviderClient)
+
+	_, err := p.Request("GET", th.Endpoint()+"debug", &gophercloud.RequestOpts{})
+	respErr, ok := err.(gophercloud.ErrDefault500)
+	if !ok {
+		t.Fatalf("Expected ErrDefault500, got %v", err)
+	}
+	th.AssertEquals(t, trace, string(respErr.Body))
+	th.AssertEquals(t, false, respErr.BodyTruncated)
+
+	p.MaxErrorBodySize = 64
+	_, err = p.Request("GET", th.Endpoint()+"debug", &gophercloud.RequestOpts{})
+	respErr = err.(gophercloud.ErrDefault500)
+	th.AssertEquals(t, trace[:64], string(respErr.Body))
+	th.AssertEquals(t, true, respErr.BodyTruncated)
+	if !strings.HasSuffix(respErr.Error(), "[response body truncated]") {
+		t.Errorf("Expected the error to mention the truncation, got %q", respErr.Error())
+	}
+
+	_, err = p.Request("GET", th.Endpoint()+"reset", &gophercloud.RequestOpts{})
+	respErr = err.(gophercloud.ErrDefault500)
+	th.AssertEquals(t, "partial", string(respErr.Body))
+	th.AssertEquals(t, true, respErr.BodyTruncated)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestTuneTransport(t *testing.T) {
+	p := new(gophercloud.ProviderClient)
+	err := p.TuneTransport(gophercloud.TransportOpts{MaxIdleConnsPerHost: 64})
+	th.AssertNoErr(t, err)
+
+	tr, ok := p.HTTPClient.Transport.(*http.Transport)
+	if !ok || tr == http.DefaultTransport {
+		t.Fatalf("Expected a new *http.Transport, got %v", p.HTTPClient.Transport)
+	}
+	th.AssertEquals(t, 64, tr.MaxIdleConnsPerHost)
+	th.AssertEquals(t, gophercloud.DefaultMaxIdleConns, tr.MaxIdleConns)
+	th.AssertEquals(t, gophercloud.DefaultIdleConnTimeout, tr.IdleConnTimeout)
+	th.AssertEquals(t, http.DefaultTransport.(*http.Transport).TLSHandshakeTimeout, tr.TLSHandshakeTimeout)
+
+	// A transport of the caller's is tuned in place.
+	tlsConfig := &tls.Config{ServerName: "example.com"}
+	own := &http.Transport{TLSClientConfig: tlsConfig}
+	p.HTTPClient.Transport = own
+	err = p.TuneTransport(gophercloud.TransportOpts{IdleConnTimeout: time.Minute})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, own, p.HTTPClient.Transport)
+	th.AssertEquals(t, tlsConfig, own.TLSClientConfig)
+	th.AssertEquals(t, time.Minute, own.IdleConnTimeout)
+	th.AssertEquals(t, gophercloud.DefaultMaxIdleConnsPerHost, own.MaxIdleConnsPerHost)
+
+	p.HTTPClient.Transport = roundTripperFunc(func(*http.Request) (*http.Response, error) { return nil, nil })
+	err = p.TuneTransport(gophercloud.TransportOpts{})
+	if _, ok := err.(gophercloud.ErrInvalidInput); !ok {
+		t.Fatalf("Expected ErrInvalidInput, got %v", err)
+	}
+}
@@ -1,9 +1,12 @@
 package testing
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/gophercloud/gophercloud"
 	th "github.com/gophercloud/gophercloud/testhelper"
@@ -32,3 +35,171 @@ func TestMoreHeaders(t *testing.T) {
 	th.AssertNoErr(t, err)
 	th.AssertEquals(t, resp.Request.Header.Get("custom"), "header")
 }
+
+func TestWithMoreHeaders(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	c := new(gophercloud.ServiceClient)
+	c.Type = "image"
+	c.Microversion = "2.5"
+	c.MoreHeaders = map[string]string{
+		"custom": "header",
+		"other":  "client",
+	}
+	c.ProviderClient = &gophercloud.ProviderClient{TokenID: "provider-token"}
+
+	oc := c.WithMoreHeaders(map[string]string{
+		"other":                 "call",
+		"OpenStack-API-Version": "image 2.7",
+		"Accept":                "",
+		"X-Auth-Token":          "accidental",
+	})
+	resp, err := oc.Get(th.Endpoint()+"route", nil, nil)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "header", resp.Request.Header.Get("custom"))
+	th.AssertEquals(t, "call", resp.Request.Header.Get("other"))
+	th.AssertEquals(t, "image 2.7", resp.Request.Header.Get("OpenStack-API-Version"))
+	th.AssertEquals(t, "", resp.Request.Header.Get("Accept"))
+	th.AssertEquals(t, "provider-token", resp.Request.Header.Get("X-Auth-Token"))
+
+	// The original client is unchanged.
+	resp, err = c.Get(th.Endpoint()+"route", nil, nil)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "client", resp.Request.Header.Get("other"))
+	th.AssertEquals(t, "image 2.5", resp.Request.Header.Get("OpenStack-API-Version"))
+	th.AssertEquals(t, "application/json", resp.Request.Header.Get("Accept"))
+
+	resp, err = c.WithAuthToken("other-token").Get(th.Endpoint()+"route", nil, nil)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "other-token", resp.Request.Header.Get("X-Auth-Token"))
+}
+
+func TestWithMicroversion(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	c := new(gophercloud.ServiceClient)
+	c.Type = "volumev2"
+	c.ProviderClient = new(gophercloud.ProviderClient)
+
+	resp, err := c.WithMicroversion("3.44").Get(th.Endpoint()+"route", nil, nil)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "3.44", resp.Request.Header.Get("X-OpenStack-Volume-API-Version"))
+	th.AssertEquals(t, "volume 3.44", resp.Request.Header.Get("OpenStack-API-Version"))
+
+	// The original client is unchanged.
+	resp, err = c.Get(th.Endpoint()+"route", nil, nil)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "", resp.Request.Header.Get("X-OpenStack-Volume-API-Version"))
+	th.AssertEquals(t, "", resp.Request.Header.Get("OpenStack-API-Version"))
+}
+
+func TestWithContext(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	c := new(gophercloud.ServiceClient)
+	c.ProviderClient = new(gophercloud.ProviderClient)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := c.WithContext(ctx).Get(th.Endpoint()+"route", nil, nil)
+	if e, ok := err.(*url.Error); !ok || e.Err != context.Canceled {
+		t.Fatalf("Expected a cancelled request, got %v", err)
+	}
+
+	// The original client is unaffected.
+	_, err = c.Get(th.Endpoint()+"route", nil, nil)
+	th.AssertNoErr(t, err)
+}
+
+type fakeMetrics struct {
+	service, method, path string
+	status                int
+	calls                 int
+}
+
+func (m *fakeMetrics) ObserveRequest(service, method, path string, status int, dur time.Duration) {
+	m.service, m.method, m.path, m.status = service, method, path, status
+	m.calls++
+}
+
+func TestMetrics(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	th.Mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	m := new(fakeMetrics)
+	c := new(gophercloud.ServiceClient)
+	c.Type = "image"
+	c.ProviderClient = &gophercloud.ProviderClient{Metrics: m}
+
+	_, err := c.Get(th.Endpoint()+"route?limit=1", nil, nil)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, m.calls)
+	th.AssertEquals(t, "image", m.service)
+	th.AssertEquals(t, "GET", m.method)
+	th.AssertEquals(t, "/route", m.path)
+	th.AssertEquals(t, http.StatusOK, m.status)
+
+	_, err = c.Delete(th.Endpoint()+"missing", nil)
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	th.AssertEquals(t, 2, m.calls)
+	th.AssertEquals(t, "DELETE", m.method)
+	th.AssertEquals(t, http.StatusNotFound, m.status)
+}
+
+func TestRequestResult(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	th.Mux.HandleFunc("/widgets", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestJSONRequest(t, r, `{"widget": {"name": "w1"}}`)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Widget", "yes")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"widget": {"id": "1", "name": "w1"}}`)
+	})
+	th.Mux.HandleFunc("/widgets/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	c := &gophercloud.ServiceClient{ProviderClient: new(gophercloud.ProviderClient), Endpoint: th.Endpoint()}
+
+	var s struct {
+		Widget struct {
+			ID string `json:"id"`
+		} `json:"widget"`
+	}
+	res := c.RequestResult("POST", c.ServiceURL("widgets"), map[string]interface{}{
+		"widget": map[string]string{"name": "w1"},
+	}, nil)
+	th.AssertNoErr(t, res.ExtractInto(&s))
+	th.AssertEquals(t, "1", s.Widget.ID)
+	th.AssertEquals(t, "yes", res.Header.Get("X-Widget"))
+
+	res = c.RequestResult("DELETE", c.ServiceURL("widgets", "1"), nil, nil)
+	th.AssertNoErr(t, res.Err)
+
+	res = c.RequestResult("GET", c.ServiceURL("gadgets"), nil, nil)
+	if _, ok := res.Err.(gophercloud.ErrDefault404); !ok {
+		t.Errorf("Expected ErrDefault404, got %v", res.Err)
+	}
+}
@@ -1,6 +1,7 @@
 package testing
 
 import (
+	"context"
 	"errors"
 	"os"
 	"path/filepath"
@@ -53,6 +54,41 @@ func TestWaitForPredicateExceed(t *testing.T) {
 	th.AssertEquals(t, "A timeout occurred", err.Error())
 }
 
+func TestPoll(t *testing.T) {
+	calls := 0
+	err := gophercloud.Poll(context.Background(), time.Millisecond, time.Second, func() (bool, error) {
+		calls++
+		return calls == 3, nil
+	})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 3, calls)
+}
+
+func TestPollTimeout(t *testing.T) {
+	err := gophercloud.Poll(context.Background(), time.Millisecond, 20*time.Millisecond, func() (bool, error) {
+		return false, nil
+	})
+	if _, ok := err.(gophercloud.ErrTimeout); !ok {
+		t.Errorf("Expected ErrTimeout, got %v", err)
+	}
+}
+
+func TestPollError(t *testing.T) {
+	err := gophercloud.Poll(context.Background(), time.Millisecond, time.Second, func() (bool, error) {
+		return false, errors.New("Error has occurred")
+	})
+	th.AssertEquals(t, "Error has occurred", err.Error())
+}
+
+func TestPollContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	err := gophercloud.Poll(ctx, time.Millisecond, 0, func() (bool, error) {
+		cancel()
+		return false, nil
+	})
+	th.AssertEquals(t, context.Canceled, err)
+}
+
 func TestNormalizeURL(t *testing.T) {
 	urls := []string{
 		"NoSlashAtEnd",
@@ -120,3 +156,17 @@ func TestNormalizePathURL(t *testing.T) {
 	th.CheckEquals(t, expected, result)
 
 }
+
+func TestNewRequestID(t *testing.T) {
+	id, err := gophercloud.NewRequestID()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, gophercloud.IsRequestID(id))
+
+	other, err := gophercloud.NewRequestID()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, false, id == other)
+
+	th.AssertEquals(t, true, gophercloud.IsRequestID("req-3791a089-9d46-4671-a3f9-55e95e55d2b4"))
+	th.AssertEquals(t, false, gophercloud.IsRequestID("3791a089-9d46-4671-a3f9-55e95e55d2b4"))
+	th.AssertEquals(t, false, gophercloud.IsRequestID("req-3791A089-9D46-4671-A3F9-55E95E55D2B4"))
+}
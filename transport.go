@@ -0,0 +1,97 @@
+package gophercloud
+
+import (
+	"net/http"
+	"time"
+)
+
+// Default values for the TransportOpts settings. They suit many concurrent
+// requests to the few hosts an OpenStack cloud exposes, where the Go defaults
+// keep only 2 idle connections per host.
+const (
+	DefaultMaxIdleConns        = 100
+	DefaultMaxIdleConnsPerHost = 32
+	DefaultIdleConnTimeout     = 90 * time.Second
+)
+
+// TransportOpts tunes the connection pool of a ProviderClient's transport.
+// It is passed to ProviderClient.TuneTransport.
+type TransportOpts struct {
+	// MaxIdleConns is the maximum number of idle connections kept across all
+	// hosts. It defaults to DefaultMaxIdleConns.
+	MaxIdleConns int
+
+	// MaxIdleConnsPerHost is the maximum number of idle connections kept per
+	// host. It defaults to DefaultMaxIdleConnsPerHost.
+	MaxIdleConnsPerHost int
+
+	// MaxConnsPerHost limits the number of connections per host, including
+	// connections in use; further requests wait for a connection. By default
+	// there is no limit. It requires Go 1.11 or later and is ignored
+	// otherwise.
+	MaxConnsPerHost int
+
+	// IdleConnTimeout is how long an idle connection is kept. It defaults to
+	// DefaultIdleConnTimeout.
+	IdleConnTimeout time.Duration
+}
+
+// TuneTransport applies opts to the connection pool of the client's HTTP
+// transport. If the client uses the default transport, it is replaced by a
+// transport with the same proxy, dial and timeout settings; a *http.Transport
+// the client was given, e.g. for its TLS configuration, is tuned in place, so
+// its other settings are kept. It must be called before the client is used.
+//
+// A transport that is not a *http.Transport cannot be tuned, and an
+// ErrInvalidInput is returned.
+func (client *ProviderClient) TuneTransport(opts TransportOpts) error {
+	var t *http.Transport
+	switch rt := client.HTTPClient.Transport.(type) {
+	case nil:
+		t = newDefaultTransport()
+	case *http.Transport:
+		if rt == http.DefaultTransport {
+			t = newDefaultTransport()
+		} else {
+			t = rt
+		}
+	default:
+		err := ErrInvalidInput{}
+		err.Argument = "ProviderClient.HTTPClient.Transport"
+		err.Value = rt
+		err.Info = "only a *http.Transport can be tuned"
+		return err
+	}
+
+	t.MaxIdleConns = DefaultMaxIdleConns
+	if opts.MaxIdleConns > 0 {
+		t.MaxIdleConns = opts.MaxIdleConns
+	}
+	t.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	if opts.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+	}
+	t.IdleConnTimeout = DefaultIdleConnTimeout
+	if opts.IdleConnTimeout > 0 {
+		t.IdleConnTimeout = opts.IdleConnTimeout
+	}
+	setMaxConnsPerHost(t, opts.MaxConnsPerHost)
+
+	client.HTTPClient.Transport = t
+	return nil
+}
+
+// newDefaultTransport returns a transport with the settings of
+// http.DefaultTransport. An http.Transport cannot be copied, so the settings
+// are copied one by one.
+func newDefaultTransport() *http.Transport {
+	t := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	if dt, ok := http.DefaultTransport.(*http.Transport); ok {
+		t.Proxy = dt.Proxy
+		t.DialContext = dt.DialContext
+		t.TLSHandshakeTimeout = dt.TLSHandshakeTimeout
+		t.ExpectContinueTimeout = dt.ExpectContinueTimeout
+		t.ResponseHeaderTimeout = dt.ResponseHeaderTimeout
+	}
+	return t
+}
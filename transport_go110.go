@@ -0,0 +1,9 @@
+// +build !go1.11
+
+package gophercloud
+
+import "net/http"
+
+// setMaxConnsPerHost does nothing: http.Transport has no MaxConnsPerHost
+// before Go 1.11.
+func setMaxConnsPerHost(t *http.Transport, n int) {}
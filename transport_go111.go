@@ -0,0 +1,9 @@
+// +build go1.11
+
+package gophercloud
+
+import "net/http"
+
+func setMaxConnsPerHost(t *http.Transport, n int) {
+	t.MaxConnsPerHost = n
+}
@@ -1,7 +1,9 @@
 package gophercloud
 
 import (
+	"context"
 	"fmt"
+	"math/rand"
 	"net/url"
 	"path/filepath"
 	"strings"
@@ -54,6 +56,50 @@ func WaitFor(timeout int, predicate func() (bool, error)) error {
 	}
 }
 
+// Poll calls condition repeatedly until it reports that it is done, it
+// returns an error, ctx is cancelled or timeout elapses. condition is first
+// called immediately and then roughly once per interval; each wait is
+// lengthened by a random jitter of up to a quarter of interval so that many
+// clients polling at once spread their requests out.
+//
+// If timeout elapses first, an ErrTimeout is returned. If ctx is cancelled
+// first, ctx.Err() is returned. A timeout of zero or less means Poll only
+// stops on ctx or condition.
+func Poll(ctx context.Context, interval, timeout time.Duration, condition func() (bool, error)) error {
+	var deadline <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	for {
+		done, err := condition()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		wait := interval
+		if jitter := int64(interval / 4); jitter > 0 {
+			wait += time.Duration(rand.Int63n(jitter))
+		}
+		tick := time.NewTimer(wait)
+
+		select {
+		case <-ctx.Done():
+			tick.Stop()
+			return ctx.Err()
+		case <-deadline:
+			tick.Stop()
+			return ErrTimeout{Timeout: timeout}
+		case <-tick.C:
+		}
+	}
+}
+
 // NormalizeURL is an internal function to be used by provider clients.
 //
 // It ensures that each endpoint URL has a closing `/`, as expected by